@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // This example demonstrates Go's basic syntax including variables, constants, and types
@@ -151,6 +154,9 @@ func demonstrateTypes() {
 	// String
 	var s string = "Hello, 世界!"
 	fmt.Printf("   String: '%s' (length: %d)\n", s, len(s))
+	
+	// len counts bytes; reversing must work on runes, not bytes
+	fmt.Printf("   Runes: %d, reversed: '%s'\n", utf8.RuneCountInString(s), ReverseString(s))
 }
 
 // demonstrateTypeConversion shows how to convert between types
@@ -160,7 +166,7 @@ func demonstrateTypeConversion() {
 	// Basic conversions
 	var i int = 42
 	var f float64 = float64(i)
-	var s string = string(i)  // This converts to Unicode character!
+	var s string = string(rune(i))  // This converts to Unicode character!
 	fmt.Printf("   int %d -> float64 %.1f -> string '%s'\n", i, f, s)
 	
 	// Proper string conversion
@@ -183,6 +189,15 @@ func demonstrateTypeConversion() {
 	// Formatting numbers to strings
 	var formatted string = strconv.FormatFloat(3.14159, 'f', 2, 64)
 	fmt.Printf("   float64 3.14159 -> string '%s' (2 decimal places)\n", formatted)
+	
+	// Parsing many values, reporting every failure
+	tokens := []string{"1", "x", "3", "y"}
+	if _, err := ParseInts(tokens); err != nil {
+		fmt.Printf("   ParseInts(%q) failed:\n", tokens)
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Printf("     %s\n", line)
+		}
+	}
 }
 
 // demonstrateOperators shows Go's operators
@@ -268,4 +283,121 @@ func demonstrateStringOperations() {
 	fmt.Printf("   Length: %d\n", len(text))
 	fmt.Printf("   First character: '%c'\n", text[0])
 	fmt.Printf("   Last character: '%c'\n", text[len(text)-1])
+	
+	// Word frequency
+	sentence := "The quick fox jumps; the lazy dog doesn't. THE END!"
+	freq := WordFrequency(sentence)
+	fmt.Printf("   Word frequency of '%s':\n", sentence)
+	fmt.Printf("     the=%d fox=%d doesn't=%d\n", freq["the"], freq["fox"], freq["doesn't"])
+	
+	// Case conversion between naming styles
+	for _, identifier := range []string{"HTTPServer", "userID", "max_retry_count"} {
+		fmt.Printf("   %s -> snake: %s, camel: %s\n", identifier, ToSnakeCase(identifier), ToCamelCase(identifier))
+	}
+}
+
+// ParseInts converts every token with strconv.Atoi. Rather than stopping at
+// the first bad token, it reports all of them, each tagged with its index,
+// in one error built with errors.Join. On error no numbers are returned, so
+// callers can't mistake a partial result for a complete one.
+func ParseInts(tokens []string) ([]int, error) {
+	numbers := make([]int, 0, len(tokens))
+	var errs []error
+	for i, token := range tokens {
+		n, err := strconv.Atoi(token)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("token %d (%q): %w", i, token, err))
+			continue
+		}
+		numbers = append(numbers, n)
+	}
+	
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return numbers, nil
+}
+
+// ReverseString reverses s rune by rune, so multibyte characters like
+// "世" survive intact. Combining marks (such as the accent in "e\u0301")
+// stay attached to the character before them.
+func ReverseString(s string) string {
+	// Group each base rune with the combining marks that follow it
+	var clusters [][]rune
+	for _, r := range s {
+		if unicode.Is(unicode.Mn, r) && len(clusters) > 0 {
+			last := len(clusters) - 1
+			clusters[last] = append(clusters[last], r)
+			continue
+		}
+		clusters = append(clusters, []rune{r})
+	}
+	
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := len(clusters) - 1; i >= 0; i-- {
+		for _, r := range clusters[i] {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ToSnakeCase converts an identifier such as "HTTPServer" or "userID" to
+// snake_case ("http_server", "user_id"). A word starts at an upper-case
+// letter that follows a lower-case letter or digit, or that ends a run of
+// capitals (an acronym). Spaces and hyphens become underscores.
+func ToSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	needSep := false
+	
+	for i, r := range runes {
+		if r == '_' || r == '-' || unicode.IsSpace(r) {
+			needSep = true
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				needSep = true
+			}
+		}
+		if needSep && b.Len() > 0 {
+			b.WriteByte('_')
+		}
+		needSep = false
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// ToCamelCase converts an identifier to lowerCamelCase, e.g.
+// "max_retry_count" -> "maxRetryCount". It splits words the same way as
+// ToSnakeCase, so "HTTPServer" becomes "httpServer".
+func ToCamelCase(s string) string {
+	words := strings.Split(ToSnakeCase(s), "_")
+	for i := 1; i < len(words); i++ {
+		r, size := utf8.DecodeRuneInString(words[i])
+		words[i] = string(unicode.ToUpper(r)) + words[i][size:]
+	}
+	return strings.Join(words, "")
+}
+
+// WordFrequency counts how often each word appears in text. Words are
+// lowercased and separated by whitespace or punctuation; apostrophes inside
+// a word ("doesn't") are kept. An empty text returns an empty map.
+func WordFrequency(text string) map[string]int {
+	freq := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+	for _, word := range words {
+		word = strings.Trim(word, "'")  // Quotes around a word aren't part of it
+		if word != "" {
+			freq[word]++
+		}
+	}
+	return freq
 }
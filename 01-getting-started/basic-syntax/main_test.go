@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWordFrequency(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected map[string]int
+	}{
+		{"simple", "go is fun", map[string]int{"go": 1, "is": 1, "fun": 1}},
+		{"punctuation", "Hello, world! Hello... world?", map[string]int{"hello": 2, "world": 2}},
+		{"mixed case", "Go GO go gO", map[string]int{"go": 4}},
+		{"no spaces around punctuation", "red,green;blue", map[string]int{"red": 1, "green": 1, "blue": 1}},
+		{"apostrophes", "don't 'quote' me", map[string]int{"don't": 1, "quote": 1, "me": 1}},
+		{"digits and unicode", "Go 1.21 in 世界", map[string]int{"go": 1, "1": 1, "21": 1, "in": 1, "世界": 1}},
+		{"empty", "", map[string]int{}},
+		{"only punctuation", "?! ...", map[string]int{}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := WordFrequency(tt.text)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("WordFrequency(%q) = %v; want %v", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReverseString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"ascii", "Hello", "olleH"},
+		{"multibyte", "Hello, 世界", "界世 ,olleH"},
+		{"emoji", "go🚀", "🚀og"},
+		{"combining accent", "cafe\u0301!", "!e\u0301fac"},
+		{"single rune", "a", "a"},
+		{"empty", "", ""},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ReverseString(tt.input)
+			if result != tt.expected {
+				t.Errorf("ReverseString(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+			if back := ReverseString(result); back != tt.input {
+				t.Errorf("ReverseString(ReverseString(%q)) = %q; want the input back", tt.input, back)
+			}
+		})
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"lower camel", "helloWorld", "hello_world"},
+		{"leading capital", "HelloWorld", "hello_world"},
+		{"acronym first", "HTTPServer", "http_server"},
+		{"acronym last", "userID", "user_id"},
+		{"acronym middle", "parseJSONBody", "parse_json_body"},
+		{"digits", "Version2Update", "version2_update"},
+		{"digits in acronym", "base64Encode", "base64_encode"},
+		{"already snake", "already_snake_case", "already_snake_case"},
+		{"spaces and hyphens", "kebab-case words", "kebab_case_words"},
+		{"single letter", "A", "a"},
+		{"empty", "", ""},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ToSnakeCase(tt.input)
+			if result != tt.expected {
+				t.Errorf("ToSnakeCase(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToCamelCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"snake", "hello_world", "helloWorld"},
+		{"leading capital", "HelloWorld", "helloWorld"},
+		{"acronym", "HTTPServer", "httpServer"},
+		{"acronym last", "user_id", "userId"},
+		{"digits", "version2_update", "version2Update"},
+		{"already camel", "alreadyCamel", "alreadyCamel"},
+		{"spaces and hyphens", "kebab-case words", "kebabCaseWords"},
+		{"single word", "go", "go"},
+		{"empty", "", ""},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ToCamelCase(tt.input)
+			if result != tt.expected {
+				t.Errorf("ToCamelCase(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseInts(t *testing.T) {
+	numbers, err := ParseInts([]string{"1", "-2", "30"})
+	if err != nil {
+		t.Fatalf("ParseInts(valid) error: %v", err)
+	}
+	if expected := []int{1, -2, 30}; !reflect.DeepEqual(numbers, expected) {
+		t.Errorf("ParseInts(valid) = %v; want %v", numbers, expected)
+	}
+	
+	numbers, err = ParseInts([]string{})
+	if err != nil || len(numbers) != 0 {
+		t.Errorf("ParseInts([]) = %v, %v; want [], nil", numbers, err)
+	}
+}
+
+func TestParseIntsReportsEveryBadToken(t *testing.T) {
+	numbers, err := ParseInts([]string{"1", "x", "3", "y", "99999999999999999999"})
+	if err == nil {
+		t.Fatal("ParseInts with bad tokens returned nil error")
+	}
+	if numbers != nil {
+		t.Errorf("ParseInts returned %v on error; want nil (no partial result)", numbers)
+	}
+	
+	for _, want := range []string{`token 1 ("x")`, `token 3 ("y")`, `token 4 ("99999999999999999999")`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "token 0") || strings.Contains(err.Error(), "token 2") {
+		t.Errorf("error %q mentions a valid token", err)
+	}
+	
+	// The underlying strconv errors are still reachable
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Errorf("errors.As(err, *strconv.NumError) = false; want true")
+	}
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("errors.Is(err, strconv.ErrRange) = false; want true for the overflowing token")
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"runtime"
@@ -355,6 +356,17 @@ func demonstratePanicRecover() {
 		fmt.Printf("     Result: %d\n", result)
 	}
 	
+	// Panic recovery with cancellation
+	fmt.Println("   Panic recovery with a deadline:")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if result, err := safeDivideCtx(ctx, 10, 2); err == nil {
+		fmt.Printf("     10 / 2 = %d\n", result)
+	}
+	if _, err := safeDivideCtx(ctx, 10, 0); err != nil {
+		fmt.Printf("     Error: %v\n", err)
+	}
+	
 	// Panic recovery in goroutine
 	fmt.Println("   Panic recovery in goroutine:")
 	go panicRecoverGoroutine()
@@ -371,7 +383,7 @@ func panicRecoverExample() {
 	
 	fmt.Println("     About to panic...")
 	panic("Something went wrong!")
-	fmt.Println("     This won't execute")
+	// fmt.Println("     This won't execute")  // Unreachable; go vet would reject it
 }
 
 // safeDivide demonstrates panic recovery in function
@@ -390,6 +402,34 @@ func safeDivide(a, b int) (result int, err error) {
 	return result, nil
 }
 
+// safeDivideCtx is safeDivide run in its own goroutine, so the caller can
+// give up when ctx is cancelled or times out. The panic is still recovered
+// inside the goroutine; an unrecovered panic there would crash the program.
+// An already-cancelled ctx returns its error without starting any work.
+func safeDivideCtx(ctx context.Context, a, b int) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	
+	type outcome struct {
+		result int
+		err    error
+	}
+	done := make(chan outcome, 1)  // Buffered so the goroutine never blocks
+	
+	go func() {
+		result, err := safeDivide(a, b)
+		done <- outcome{result, err}
+	}()
+	
+	select {
+	case out := <-done:
+		return out.result, out.err
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
 // panicRecoverGoroutine demonstrates panic recovery in goroutine
 func panicRecoverGoroutine() {
 	defer func() {
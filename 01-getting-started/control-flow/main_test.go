@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSafeDivideCtx(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	
+	t.Run("success", func(t *testing.T) {
+		result, err := safeDivideCtx(ctx, 10, 3)
+		if err != nil || result != 3 {
+			t.Errorf("safeDivideCtx(ctx, 10, 3) = %d, %v; want 3, nil", result, err)
+		}
+	})
+	
+	t.Run("recovers panic", func(t *testing.T) {
+		_, err := safeDivideCtx(ctx, 10, 0)
+		if err == nil || !strings.Contains(err.Error(), "division by zero") {
+			t.Errorf("safeDivideCtx(ctx, 10, 0) error = %v; want recovered division by zero", err)
+		}
+	})
+	
+	t.Run("cancelled context", func(t *testing.T) {
+		cancelled, cancelNow := context.WithCancel(context.Background())
+		cancelNow()
+		_, err := safeDivideCtx(cancelled, 10, 2)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("safeDivideCtx(cancelled, 10, 2) error = %v; want context.Canceled", err)
+		}
+	})
+}
@@ -1,7 +1,13 @@
 package main
 
 import (
+	"cmp"
+	"container/list"
 	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"slices"
 	"strings"
 )
 
@@ -28,6 +34,18 @@ func main() {
 	
 	// Demonstrate memory management
 	demonstrateMemoryManagement()
+	
+	// Demonstrate generic slice helpers
+	demonstrateSliceHelpers()
+	
+	// Demonstrate binary search trees
+	demonstrateBinarySearchTree()
+	
+	// Demonstrate approximate distinct counting
+	demonstrateApproxDistinct()
+	
+	// Demonstrate generic collections
+	demonstrateCollections()
 }
 
 // demonstrateArrays shows array operations
@@ -115,6 +133,11 @@ func demonstrateSlices() {
 	copy(slice12, slice11)
 	fmt.Printf("   Copied slice: %v\n", slice12)
 	
+	// Deleting (in place, clearing the vacated tail slot)
+	slice15 := []string{"a", "b", "c", "d"}
+	slice15 = DeleteAt(slice15, 1)
+	fmt.Printf("   After DeleteAt(1): %v (len: %d, cap: %d)\n", slice15, len(slice15), cap(slice15))
+	
 	// Slice internals demonstration
 	fmt.Println("\n   Slice internals:")
 	slice13 := []int{1, 2, 3, 4, 5}
@@ -189,6 +212,19 @@ func demonstrateMaps() {
 		},
 	}
 	fmt.Printf("   Nested map: %v\n", m4)
+	
+	total := 0
+	WalkNestedMap(m4, func(outer, inner string, value int) {
+		total += value
+	})
+	fmt.Printf("   Sum of nested values: %d\n", total)
+	
+	// Reconciling two configuration states
+	current := map[string]string{"host": "localhost", "port": "8080", "debug": "true"}
+	desired := map[string]string{"host": "example.com", "port": "8080", "timeout": "30s"}
+	applied, removed := ApplyConfigChanges(current, desired)
+	fmt.Printf("   Config changes to apply: %v\n", applied)
+	fmt.Printf("   Config keys to remove: %v\n", removed)
 }
 
 // demonstrateStructs shows struct operations
@@ -215,15 +251,6 @@ func demonstrateStructs() {
 	fmt.Printf("   Partial struct: %+v\n", p4)
 	
 	// Struct with different field types
-	type User struct {
-		ID       int
-		Username string
-		Email    string
-		Active   bool
-		Scores   []int
-		Profile  map[string]string
-	}
-	
 	user := User{
 		ID:       1,
 		Username: "alice",
@@ -234,6 +261,14 @@ func demonstrateStructs() {
 	}
 	fmt.Printf("   Complex struct: %+v\n", user)
 	
+	// Assignment copies the slice and map headers, not their contents;
+	// Clone makes a deep copy that can be changed independently
+	clone := user.Clone()
+	clone.Scores[0] = 0
+	clone.Profile["city"] = "Chicago"
+	fmt.Printf("   Clone after mutation: Scores=%v Profile=%v\n", clone.Scores, clone.Profile)
+	fmt.Printf("   Original intact: Scores=%v Profile=%v\n", user.Scores, user.Profile)
+	
 	// Anonymous struct
 	anon := struct {
 		Name string
@@ -329,6 +364,16 @@ func demonstratePointers() {
 	arr2[0] = 100
 	fmt.Printf("   Original array: %v\n", arr)
 	fmt.Printf("   Modified array: %v\n", arr2)
+	
+	// Linked list: nodes chained together by pointers
+	var letters LinkedList[string]
+	letters.PushBack("b")
+	letters.PushBack("c")
+	letters.PushFront("a")
+	fmt.Printf("   Linked list: %v (len %d)\n", letters.ToSlice(), letters.Len())
+	if head, ok := letters.PopFront(); ok {
+		fmt.Printf("   PopFront: %s, remaining %v\n", head, letters.ToSlice())
+	}
 }
 
 // demonstrateMemoryManagement shows memory management concepts
@@ -366,6 +411,856 @@ func demonstrateMemoryManagement() {
 	fmt.Printf("   Built string: %s\n", result)
 }
 
+// demonstrateSliceHelpers shows generic helpers built on top of slices
+func demonstrateSliceHelpers() {
+	fmt.Println("\n7. Generic Slice Helpers:")
+	
+	// Compact removes zero values
+	numbers := []int{0, 1, 0, 2, 3, 0}
+	fmt.Printf("   Compact(%v) = %v\n", numbers, Compact(numbers))
+	
+	words := []string{"go", "", "is", "", "fun"}
+	fmt.Printf("   Compact(%q) = %q\n", words, Compact(words))
+	
+	// CompactFunc lets the caller decide what "empty" means
+	people := []Person{{Name: "Alice", Age: 30}, {Name: ""}, {Name: "Bob", Age: 25}}
+	named := CompactFunc(people, func(p Person) bool {
+		return p.Name == ""
+	})
+	fmt.Printf("   CompactFunc(people, no name) = %+v\n", named)
+	
+	// SortedUnique sorts and removes duplicates in one pass
+	unsorted := []int{3, 1, 2, 3, 1}
+	fmt.Printf("   SortedUnique(%v) = %v\n", unsorted, SortedUnique(unsorted))
+	
+	// Comparing two tag sets
+	postTags := []string{"go", "generics", "tutorial"}
+	relatedTags := []string{"go", "tutorial", "beginner"}
+	fmt.Printf("   SymmetricDifference(%v, %v) = %v\n", postTags, relatedTags, SymmetricDifference(postTags, relatedTags))
+	fmt.Printf("   Jaccard similarity: %.2f\n", Jaccard(postTags, relatedTags))
+	
+	// Chunk splits work into batches
+	ids := []int{1, 2, 3, 4, 5, 6, 7}
+	fmt.Printf("   Chunk(%v, 3) = %v\n", ids, Chunk(ids, 3))
+}
+
+// demonstrateBinarySearchTree shows a generic binary search tree
+func demonstrateBinarySearchTree() {
+	fmt.Println("\n8. Binary Search Tree:")
+	
+	tree := &BST[int]{}
+	for _, v := range []int{5, 3, 8, 1, 4, 9} {
+		tree.Insert(v)
+	}
+	fmt.Printf("   In-order traversal: %v\n", tree.InOrder())
+	
+	// Serialize flattens the tree level by level, using nil for missing children
+	data := tree.Serialize()
+	fmt.Printf("   Serialized: %s\n", formatSerialized(data))
+	
+	// Deserialize rebuilds an identical tree from the flat slice
+	restored := Deserialize(data)
+	fmt.Printf("   Restored in-order: %v\n", restored.InOrder())
+	
+	// DepthFirst uses an explicit stack, so depth is limited by heap, not call stack
+	fmt.Printf("   Depth-first (pre-order): %v\n", tree.DepthFirst())
+	
+	deep := &BST[int]{}
+	for i := 10000; i > 0; i-- {
+		deep.Insert(i)  // Descending inserts build a left-skewed "linked list"
+	}
+	visited := deep.DepthFirst()
+	fmt.Printf("   Deep tree: visited %d nodes, first %d, last %d\n",
+		len(visited), visited[0], visited[len(visited)-1])
+}
+
+// demonstrateApproxDistinct shows counting distinct items in bounded memory
+func demonstrateApproxDistinct() {
+	fmt.Println("\n9. Approximate Distinct Counting:")
+	
+	// Small streams are counted exactly
+	small := NewApproxDistinct()
+	for _, word := range strings.Fields("the quick brown fox jumps over the lazy dog") {
+		small.Add([]byte(word))
+	}
+	fmt.Printf("   Distinct words: %d\n", small.Estimate())
+	
+	// Large streams switch to a fixed-size sketch
+	large := NewApproxDistinct()
+	for i := 0; i < 500000; i++ {
+		large.Add([]byte(fmt.Sprintf("user-%d", i%100000)))  // 100000 distinct users, each seen 5 times
+	}
+	estimate := large.Estimate()
+	errorPct := math.Abs(float64(estimate)-100000) / 100000 * 100
+	fmt.Printf("   Estimated distinct users: %d (actual 100000, error %.1f%%)\n", estimate, errorPct)
+	fmt.Printf("   Sketch size: %d bytes\n", len(large.registers))
+}
+
+// demonstrateCollections shows generic container types built on slices and maps
+func demonstrateCollections() {
+	fmt.Println("\n10. Generic Collections:")
+	
+	// Stack (LIFO)
+	var stack Stack[int]
+	for i := 1; i <= 3; i++ {
+		stack.Push(i)
+	}
+	if top, ok := stack.Peek(); ok {
+		fmt.Printf("   Stack top: %d (len %d)\n", top, stack.Len())
+	}
+	fmt.Print("   Popped:")
+	for {
+		v, ok := stack.Pop()
+		if !ok {
+			break
+		}
+		fmt.Printf(" %d", v)
+	}
+	fmt.Println()
+	
+	// Queue (FIFO)
+	var queue Queue[string]
+	for _, task := range []string{"download", "parse", "store"} {
+		queue.Enqueue(task)
+	}
+	fmt.Print("   Dequeued:")
+	for queue.Len() > 0 {
+		task, _ := queue.Dequeue()
+		fmt.Printf(" %s", task)
+	}
+	fmt.Println()
+	
+	// Set algebra
+	evens := NewSet(2, 4, 6, 8)
+	smalls := NewSet(1, 2, 3, 4)
+	fmt.Printf("   Union: %v\n", sortedItems(evens.Union(smalls)))
+	fmt.Printf("   Intersection: %v\n", sortedItems(evens.Intersection(smalls)))
+	fmt.Printf("   Difference (evens - smalls): %v\n", sortedItems(evens.Difference(smalls)))
+	fmt.Printf("   evens contains 6: %t\n", evens.Contains(6))
+	
+	// OrderedMap keeps insertion order, unlike the built-in map
+	steps := NewOrderedMap[string, int]()
+	steps.Set("checkout", 1)
+	steps.Set("build", 2)
+	steps.Set("test", 3)
+	steps.Delete("checkout")
+	steps.Set("checkout", 4)  // Re-adding moves the key to the end
+	fmt.Print("   OrderedMap:")
+	steps.Range(func(key string, value int) bool {
+		fmt.Printf(" %s=%d", key, value)
+		return true
+	})
+	fmt.Println()
+}
+
+// sortedItems lists a set's items in order, since map order is random
+func sortedItems[T cmp.Ordered](s Set[T]) []T {
+	items := s.Items()
+	slices.Sort(items)
+	return items
+}
+
+// Compact returns a new slice with the zero values of T removed.
+// The order of the remaining elements is preserved and s is not modified.
+// A nil slice returns nil; a slice of only zero values returns an empty slice.
+func Compact[T comparable](s []T) []T {
+	var zero T
+	return CompactFunc(s, func(v T) bool {
+		return v == zero
+	})
+}
+
+// CompactFunc is like Compact but uses isEmpty to decide which elements to drop
+func CompactFunc[T any](s []T, isEmpty func(T) bool) []T {
+	if s == nil {
+		return nil
+	}
+	
+	result := make([]T, 0, len(s))
+	for _, v := range s {
+		if !isEmpty(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// DeleteAt removes the element at index i in place and returns the shorter
+// slice. The vacated last slot of the backing array is set to the zero value
+// so it doesn't keep pointers alive for the garbage collector.
+// It panics if i is out of range.
+func DeleteAt[T any](s []T, i int) []T {
+	_ = s[i]  // Bounds check
+	copy(s[i:], s[i+1:])
+	var zero T
+	s[len(s)-1] = zero
+	return s[:len(s)-1]
+}
+
+// Chunk splits s into consecutive sub-slices of at most size elements; only
+// the last chunk may be shorter. The chunks share s's backing array but are
+// capped, so appending to one cannot overwrite the next.
+// A size <= 0 is treated as "no limit": a non-empty s comes back as a single
+// chunk. An empty s returns nil.
+func Chunk[T any](s []T, size int) [][]T {
+	if len(s) == 0 {
+		return nil
+	}
+	if size <= 0 || size > len(s) {
+		size = len(s)
+	}
+	
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for start := 0; start < len(s); start += size {
+		end := min(start+size, len(s))
+		chunks = append(chunks, s[start:end:end])
+	}
+	return chunks
+}
+
+// formatSerialized renders a serialized tree, printing nil markers as "nil"
+func formatSerialized[T any](data []*T) string {
+	parts := make([]string, len(data))
+	for i, v := range data {
+		if v == nil {
+			parts[i] = "nil"
+		} else {
+			parts[i] = fmt.Sprint(*v)
+		}
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// TreeNode is a single node of a binary search tree
+type TreeNode[T cmp.Ordered] struct {
+	Value T
+	Left  *TreeNode[T]
+	Right *TreeNode[T]
+}
+
+// BST is a generic binary search tree; the zero value is an empty tree
+type BST[T cmp.Ordered] struct {
+	root *TreeNode[T]
+}
+
+// Insert adds v to the tree. Duplicates go to the right subtree.
+func (t *BST[T]) Insert(v T) {
+	node := &TreeNode[T]{Value: v}
+	if t.root == nil {
+		t.root = node
+		return
+	}
+	
+	current := t.root
+	for {
+		if v < current.Value {
+			if current.Left == nil {
+				current.Left = node
+				return
+			}
+			current = current.Left
+		} else {
+			if current.Right == nil {
+				current.Right = node
+				return
+			}
+			current = current.Right
+		}
+	}
+}
+
+// InOrder returns the tree values in sorted order
+func (t *BST[T]) InOrder() []T {
+	var result []T
+	var walk func(n *TreeNode[T])
+	walk = func(n *TreeNode[T]) {
+		if n == nil {
+			return
+		}
+		walk(n.Left)
+		result = append(result, n.Value)
+		walk(n.Right)
+	}
+	walk(t.root)
+	return result
+}
+
+// DepthFirst returns the values in pre-order (node, left, right).
+// It walks the tree iteratively with an explicit stack instead of
+// recursion, so very deep, unbalanced trees can't exhaust the call stack.
+func (t *BST[T]) DepthFirst() []T {
+	if t.root == nil {
+		return nil
+	}
+	
+	var result []T
+	stack := []*TreeNode[T]{t.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		result = append(result, node.Value)
+		
+		// Push right first so the left subtree is visited first
+		if node.Right != nil {
+			stack = append(stack, node.Right)
+		}
+		if node.Left != nil {
+			stack = append(stack, node.Left)
+		}
+	}
+	return result
+}
+
+// Serialize flattens the tree in level order. Missing children are
+// recorded as nil markers so the exact shape can be rebuilt; trailing
+// nil markers are trimmed. An empty tree serializes to an empty slice.
+func (t *BST[T]) Serialize() []*T {
+	var result []*T
+	queue := []*TreeNode[T]{t.root}
+	
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		
+		if node == nil {
+			result = append(result, nil)
+			continue
+		}
+		
+		value := node.Value
+		result = append(result, &value)
+		queue = append(queue, node.Left, node.Right)
+	}
+	
+	// Trim trailing nil markers
+	for len(result) > 0 && result[len(result)-1] == nil {
+		result = result[:len(result)-1]
+	}
+	return result
+}
+
+// Deserialize rebuilds a tree produced by Serialize
+func Deserialize[T cmp.Ordered](data []*T) *BST[T] {
+	tree := &BST[T]{}
+	if len(data) == 0 || data[0] == nil {
+		return tree
+	}
+	
+	tree.root = &TreeNode[T]{Value: *data[0]}
+	queue := []*TreeNode[T]{tree.root}
+	i := 1
+	
+	for len(queue) > 0 && i < len(data) {
+		node := queue[0]
+		queue = queue[1:]
+		
+		if i < len(data) && data[i] != nil {
+			node.Left = &TreeNode[T]{Value: *data[i]}
+			queue = append(queue, node.Left)
+		}
+		i++
+		
+		if i < len(data) && data[i] != nil {
+			node.Right = &TreeNode[T]{Value: *data[i]}
+			queue = append(queue, node.Right)
+		}
+		i++
+	}
+	return tree
+}
+
+// SortedUnique returns a new sorted slice with duplicates removed.
+// Sorting first puts equal values next to each other, so duplicates can be
+// dropped in place with a single pass and no extra map.
+// A nil slice returns nil and s itself is never modified.
+func SortedUnique[T cmp.Ordered](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	
+	result := make([]T, len(s))
+	copy(result, s)
+	slices.Sort(result)
+	
+	if len(result) < 2 {
+		return result
+	}
+	
+	// write is the index of the last unique element kept so far
+	write := 0
+	for read := 1; read < len(result); read++ {
+		if result[read] != result[write] {
+			write++
+			result[write] = result[read]
+		}
+	}
+	return result[:write+1]
+}
+
+// SymmetricDifference returns the elements that are in exactly one of a
+// and b. Both slices are treated as sets, so duplicates are ignored. The
+// result lists a's unique elements first, then b's, each in their original
+// order; identical sets yield an empty slice.
+func SymmetricDifference[T comparable](a, b []T) []T {
+	inA := NewSet(a...)
+	inB := NewSet(b...)
+	
+	result := []T{}
+	seen := NewSet[T]()
+	for _, items := range [][]T{a, b} {
+		for _, item := range items {
+			if inA.Contains(item) != inB.Contains(item) && !seen.Contains(item) {
+				seen.Add(item)
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// Jaccard returns the Jaccard similarity of a and b treated as sets: the
+// size of their intersection divided by the size of their union, from 0
+// (disjoint) to 1 (identical). Two empty sets are identical, so they have
+// a similarity of 1.
+func Jaccard[T comparable](a, b []T) float64 {
+	inA := NewSet(a...)
+	inB := NewSet(b...)
+	
+	union := inA.Union(inB).Len()
+	if union == 0 {
+		return 1
+	}
+	return float64(inA.Intersection(inB).Len()) / float64(union)
+}
+
+// ApplyConfigChanges compares current with desired and returns the keys
+// that must be set (new or changed, with their desired values) and the
+// keys that must be removed, sorted. Identical states need no changes and
+// yield an empty map and a nil slice. Neither input is modified.
+func ApplyConfigChanges(current, desired map[string]string) (applied map[string]string, removed []string) {
+	applied = make(map[string]string)
+	for key, value := range desired {
+		if old, exists := current[key]; !exists || old != value {
+			applied[key] = value
+		}
+	}
+	
+	for key := range current {
+		if _, exists := desired[key]; !exists {
+			removed = append(removed, key)
+		}
+	}
+	slices.Sort(removed)
+	
+	return applied, removed
+}
+
+// WalkNestedMap calls visit for every leaf value in m. Go randomizes map
+// iteration order, so both outer and inner keys are visited in sorted
+// order to make the walk deterministic.
+func WalkNestedMap(m map[string]map[string]int, visit func(outer, inner string, value int)) {
+	for _, outer := range sortedKeys(m) {
+		inners := m[outer]
+		for _, inner := range sortedKeys(inners) {
+			visit(outer, inner, inners[inner])
+		}
+	}
+}
+
+// sortedKeys returns the keys of m in ascending order
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+const (
+	// approxPrecision is the number of hash bits used to pick a register;
+	// 2^10 registers give a standard error of about 1.04/sqrt(1024) ≈ 3%
+	approxPrecision = 10
+	approxRegisters = 1 << approxPrecision
+	
+	// exactThreshold is how many distinct items are counted exactly before
+	// switching to the sketch alone
+	exactThreshold = 1000
+)
+
+// ApproxDistinct estimates the number of distinct items in a stream using
+// HyperLogLog, a refinement of LogLog. Memory stays fixed at one byte per
+// register however many items are added. Until exactThreshold distinct
+// items have been seen it also keeps an exact set, so small counts are
+// precise.
+type ApproxDistinct struct {
+	registers []uint8
+	exact     map[string]struct{}
+}
+
+// NewApproxDistinct creates an empty estimator
+func NewApproxDistinct() *ApproxDistinct {
+	return &ApproxDistinct{
+		registers: make([]uint8, approxRegisters),
+		exact:     make(map[string]struct{}),
+	}
+}
+
+// Add records an item in the stream
+func (a *ApproxDistinct) Add(item []byte) {
+	if a.exact != nil {
+		a.exact[string(item)] = struct{}{}
+		if len(a.exact) > exactThreshold {
+			a.exact = nil  // From now on only the sketch is used
+		}
+	}
+	
+	h := hashItem(item)
+	index := h >> (64 - approxPrecision)
+	// The rank is the position of the first 1 bit in the remaining bits;
+	// the guard bit caps it when they are all zero
+	rest := h<<approxPrecision | 1<<(approxPrecision-1)
+	rank := uint8(bits.LeadingZeros64(rest) + 1)
+	if rank > a.registers[index] {
+		a.registers[index] = rank
+	}
+}
+
+// Estimate returns the (approximate) number of distinct items added
+func (a *ApproxDistinct) Estimate() uint64 {
+	if a.exact != nil {
+		return uint64(len(a.exact))
+	}
+	
+	m := float64(approxRegisters)
+	sum := 0.0
+	zeros := 0
+	for _, r := range a.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	
+	// For small cardinalities linear counting on empty registers is more accurate
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// hashItem hashes item with FNV-1a and mixes the result, since HyperLogLog
+// needs well-distributed high bits
+func hashItem(item []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(item)
+	x := h.Sum64()
+	
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// Stack is a last-in, first-out collection; the zero value is an empty stack
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v to the top of the stack
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+// Pop removes and returns the top element. ok is false if the stack is empty.
+func (s *Stack[T]) Pop() (v T, ok bool) {
+	if len(s.items) == 0 {
+		return v, false
+	}
+	
+	last := len(s.items) - 1
+	v = s.items[last]
+	var zero T
+	s.items[last] = zero  // Don't keep a reference to the popped value
+	s.items = s.items[:last]
+	return v, true
+}
+
+// Peek returns the top element without removing it
+func (s *Stack[T]) Peek() (v T, ok bool) {
+	if len(s.items) == 0 {
+		return v, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Len returns the number of elements on the stack
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// Queue is a first-in, first-out collection backed by a slice; the zero
+// value is an empty queue. Enqueue appends to the slice and Dequeue
+// advances a head index, so both are amortized O(1). The consumed prefix
+// is reclaimed once it makes up more than half of the slice, so a long-lived
+// queue doesn't grow without bound.
+type Queue[T any] struct {
+	items []T
+	head  int
+}
+
+// Enqueue adds v to the back of the queue
+func (q *Queue[T]) Enqueue(v T) {
+	q.items = append(q.items, v)
+}
+
+// Dequeue removes and returns the front element. ok is false if the queue is empty.
+func (q *Queue[T]) Dequeue() (v T, ok bool) {
+	if q.head == len(q.items) {
+		return v, false
+	}
+	
+	v = q.items[q.head]
+	var zero T
+	q.items[q.head] = zero  // Don't keep a reference to the dequeued value
+	q.head++
+	
+	if q.head > len(q.items)/2 {
+		n := copy(q.items, q.items[q.head:])
+		clear(q.items[n:])  // The tail still holds copies of the moved elements
+		q.items = q.items[:n]
+		q.head = 0
+	}
+	return v, true
+}
+
+// Len returns the number of elements waiting in the queue
+func (q *Queue[T]) Len() int {
+	return len(q.items) - q.head
+}
+
+// Set is an unordered collection of unique values. The zero value is an
+// empty set ready to use. Union, Intersection and Difference return new
+// sets and never modify their operands.
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+// NewSet creates a set holding items, ignoring duplicates
+func NewSet[T comparable](items ...T) Set[T] {
+	s := Set[T]{items: make(map[T]struct{}, len(items))}
+	for _, item := range items {
+		s.items[item] = struct{}{}
+	}
+	return s
+}
+
+// Add inserts v; adding a value already in the set does nothing
+func (s *Set[T]) Add(v T) {
+	if s.items == nil {
+		s.items = make(map[T]struct{})
+	}
+	s.items[v] = struct{}{}
+}
+
+// Remove deletes v if present
+func (s *Set[T]) Remove(v T) {
+	delete(s.items, v)
+}
+
+// Contains reports whether v is in the set
+func (s Set[T]) Contains(v T) bool {
+	_, ok := s.items[v]
+	return ok
+}
+
+// Len returns the number of values in the set
+func (s Set[T]) Len() int {
+	return len(s.items)
+}
+
+// Items returns the values in unspecified order
+func (s Set[T]) Items() []T {
+	items := make([]T, 0, len(s.items))
+	for item := range s.items {
+		items = append(items, item)
+	}
+	return items
+}
+
+// Union returns the values in s, other, or both
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	result := NewSet[T]()
+	for item := range s.items {
+		result.items[item] = struct{}{}
+	}
+	for item := range other.items {
+		result.items[item] = struct{}{}
+	}
+	return result
+}
+
+// Intersection returns the values in both s and other
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	result := NewSet[T]()
+	for item := range s.items {
+		if other.Contains(item) {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference returns the values in s that are not in other
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	result := NewSet[T]()
+	for item := range s.items {
+		if !other.Contains(item) {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// OrderedMap is a map that remembers insertion order. Updating an existing
+// key keeps its position; deleting it and adding it again moves it to the end.
+type OrderedMap[K comparable, V any] struct {
+	entries map[K]*list.Element
+	order   *list.List  // Elements hold *orderedEntry values, oldest first
+}
+
+type orderedEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewOrderedMap creates an empty OrderedMap
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		entries: make(map[K]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Set stores value under key, appending key if it is new
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*orderedEntry[K, V]).value = value
+		return
+	}
+	m.entries[key] = m.order.PushBack(&orderedEntry[K, V]{key: key, value: value})
+}
+
+// Get returns the value stored under key
+func (m *OrderedMap[K, V]) Get(key K) (value V, ok bool) {
+	elem, ok := m.entries[key]
+	if !ok {
+		return value, false
+	}
+	return elem.Value.(*orderedEntry[K, V]).value, true
+}
+
+// Delete removes key if present
+func (m *OrderedMap[K, V]) Delete(key K) {
+	if elem, ok := m.entries[key]; ok {
+		m.order.Remove(elem)
+		delete(m.entries, key)
+	}
+}
+
+// Len returns the number of keys
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Keys returns the keys in insertion order
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.entries))
+	m.Range(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Range calls fn for each entry in insertion order until fn returns false
+func (m *OrderedMap[K, V]) Range(fn func(key K, value V) bool) {
+	for elem := m.order.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(*orderedEntry[K, V])
+		if !fn(entry.key, entry.value) {
+			return
+		}
+	}
+}
+
+// LinkedList is a singly linked list. The zero value is an empty list.
+// It keeps a tail pointer so PushBack is O(1) as well as PushFront.
+type LinkedList[T any] struct {
+	head *listNode[T]
+	tail *listNode[T]
+	size int
+}
+
+type listNode[T any] struct {
+	value T
+	next  *listNode[T]
+}
+
+// PushFront adds v to the start of the list
+func (l *LinkedList[T]) PushFront(v T) {
+	l.head = &listNode[T]{value: v, next: l.head}
+	if l.tail == nil {
+		l.tail = l.head
+	}
+	l.size++
+}
+
+// PushBack adds v to the end of the list
+func (l *LinkedList[T]) PushBack(v T) {
+	node := &listNode[T]{value: v}
+	if l.tail == nil {
+		l.head = node
+	} else {
+		l.tail.next = node
+	}
+	l.tail = node
+	l.size++
+}
+
+// PopFront removes and returns the first element.
+// ok is false when the list is empty.
+func (l *LinkedList[T]) PopFront() (v T, ok bool) {
+	if l.head == nil {
+		return v, false
+	}
+	node := l.head
+	l.head = node.next
+	if l.head == nil {
+		l.tail = nil
+	}
+	l.size--
+	return node.value, true
+}
+
+// Len returns the number of elements
+func (l *LinkedList[T]) Len() int {
+	return l.size
+}
+
+// ToSlice returns the elements from front to back
+func (l *LinkedList[T]) ToSlice() []T {
+	out := make([]T, 0, l.size)
+	for node := l.head; node != nil; node = node.next {
+		out = append(out, node.value)
+	}
+	return out
+}
+
 // Rectangle struct for demonstration
 type Rectangle struct {
 	Width  float64
@@ -403,3 +1298,29 @@ func (p Person) GetName() string {
 func (p *Person) SetAge(age int) {
 	p.Age = age
 }
+
+// User struct with reference-type fields
+type User struct {
+	ID       int
+	Username string
+	Email    string
+	Active   bool
+	Scores   []int
+	Profile  map[string]string
+}
+
+// Clone returns a deep copy of u. Scores and Profile get their own
+// backing storage; nil fields stay nil.
+func (u User) Clone() User {
+	clone := u
+	if u.Scores != nil {
+		clone.Scores = append([]int(nil), u.Scores...)
+	}
+	if u.Profile != nil {
+		clone.Profile = make(map[string]string, len(u.Profile))
+		for k, v := range u.Profile {
+			clone.Profile[k] = v
+		}
+	}
+	return clone
+}
@@ -0,0 +1,745 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCompactInts(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int
+		expected []int
+	}{
+		{"mixed values", []int{0, 1, 0, 2, 3, 0}, []int{1, 2, 3}},
+		{"no zeros", []int{1, 2, 3}, []int{1, 2, 3}},
+		{"all zeros", []int{0, 0, 0}, []int{}},
+		{"empty", []int{}, []int{}},
+		{"nil", nil, nil},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Compact(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Compact(%v) = %#v; want %#v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompactStrings(t *testing.T) {
+	input := []string{"", "go", "", "is", "fun", ""}
+	original := append([]string(nil), input...)
+	
+	result := Compact(input)
+	expected := []string{"go", "is", "fun"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Compact(%q) = %q; want %q", input, result, expected)
+	}
+	
+	if !reflect.DeepEqual(input, original) {
+		t.Errorf("Compact mutated its input: got %q; want %q", input, original)
+	}
+}
+
+func TestCompactFuncStructs(t *testing.T) {
+	people := []Person{
+		{Name: "Alice", Age: 30},
+		{Name: "", Age: 40},
+		{Name: "Bob", Age: 25},
+		{},
+	}
+	
+	result := CompactFunc(people, func(p Person) bool {
+		return p.Name == ""
+	})
+	expected := []Person{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 25}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("CompactFunc() = %+v; want %+v", result, expected)
+	}
+	
+	if CompactFunc[Person](nil, func(Person) bool { return true }) != nil {
+		t.Error("CompactFunc(nil) should return nil")
+	}
+}
+
+func TestBSTSerializeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+	}{
+		{"empty", nil},
+		{"single node", []int{42}},
+		{"balanced", []int{5, 3, 8, 1, 4, 7, 9}},
+		{"left skewed", []int{5, 4, 3, 2, 1}},
+		{"right skewed", []int{1, 2, 3, 4, 5}},
+		{"zig zag", []int{10, 2, 8, 4, 6}},
+		{"duplicates", []int{3, 3, 1, 3}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := &BST[int]{}
+			for _, v := range tt.values {
+				tree.Insert(v)
+			}
+			
+			data := tree.Serialize()
+			restored := Deserialize(data)
+			
+			if !reflect.DeepEqual(restored.InOrder(), tree.InOrder()) {
+				t.Errorf("InOrder() after round trip = %v; want %v",
+					restored.InOrder(), tree.InOrder())
+			}
+			
+			// The shape must survive too, not just the values
+			if !reflect.DeepEqual(restored.Serialize(), data) {
+				t.Errorf("Serialize() after round trip = %s; want %s",
+					formatSerialized(restored.Serialize()), formatSerialized(data))
+			}
+		})
+	}
+}
+
+func TestBSTSerializeEmpty(t *testing.T) {
+	tree := &BST[string]{}
+	data := tree.Serialize()
+	if len(data) != 0 {
+		t.Errorf("Serialize() of empty tree = %s; want []", formatSerialized(data))
+	}
+	
+	if restored := Deserialize(data); restored.InOrder() != nil {
+		t.Errorf("Deserialize(empty).InOrder() = %v; want nil", restored.InOrder())
+	}
+}
+
+func TestSortedUnique(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int
+		expected []int
+	}{
+		{"nil", nil, nil},
+		{"empty", []int{}, []int{}},
+		{"single element", []int{7}, []int{7}},
+		{"all duplicates", []int{4, 4, 4, 4}, []int{4}},
+		{"mixed", []int{3, 1, 2, 3, 1}, []int{1, 2, 3}},
+		{"negatives", []int{0, -1, 5, -1, 0}, []int{-1, 0, 5}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append([]int(nil), tt.input...)
+			result := SortedUnique(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("SortedUnique(%v) = %#v; want %#v", tt.input, result, tt.expected)
+			}
+			if len(tt.input) > 0 && !reflect.DeepEqual(tt.input, original) {
+				t.Errorf("SortedUnique mutated its input: %v", tt.input)
+			}
+		})
+	}
+}
+
+// naiveSortedUnique dedups through a map first and then sorts the keys
+func naiveSortedUnique(s []int) []int {
+	seen := make(map[int]struct{})
+	for _, v := range s {
+		seen[v] = struct{}{}
+	}
+	result := make([]int, 0, len(seen))
+	for v := range seen {
+		result = append(result, v)
+	}
+	sort.Ints(result)
+	return result
+}
+
+func generateDuplicates(size int) []int {
+	data := make([]int, size)
+	for i := range data {
+		data[i] = (i * 7919) % (size / 4)
+	}
+	return data
+}
+
+func BenchmarkSortedUnique(b *testing.B) {
+	data := generateDuplicates(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	
+	for i := 0; i < b.N; i++ {
+		SortedUnique(data)
+	}
+}
+
+func BenchmarkNaiveSortedUnique(b *testing.B) {
+	data := generateDuplicates(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	
+	for i := 0; i < b.N; i++ {
+		naiveSortedUnique(data)
+	}
+}
+
+func TestBSTDepthFirst(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int
+		expected []int
+	}{
+		{"empty", nil, nil},
+		{"single node", []int{1}, []int{1}},
+		{"balanced", []int{5, 3, 8, 1, 4, 9}, []int{5, 3, 1, 4, 8, 9}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := &BST[int]{}
+			for _, v := range tt.values {
+				tree.Insert(v)
+			}
+			if got := tree.DepthFirst(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("DepthFirst() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBSTDepthFirstDeepTree(t *testing.T) {
+	const depth = 10000
+	
+	// Inserting in descending order makes every node a left child
+	tree := &BST[int]{}
+	for i := depth; i > 0; i-- {
+		tree.Insert(i)
+	}
+	
+	result := tree.DepthFirst()
+	if len(result) != depth {
+		t.Fatalf("DepthFirst() visited %d nodes; want %d", len(result), depth)
+	}
+	for i, v := range result {
+		if v != depth-i {
+			t.Fatalf("DepthFirst()[%d] = %d; want %d", i, v, depth-i)
+		}
+	}
+}
+
+func TestApplyConfigChanges(t *testing.T) {
+	tests := []struct {
+		name            string
+		current         map[string]string
+		desired         map[string]string
+		expectedApplied map[string]string
+		expectedRemoved []string
+	}{
+		{
+			name:            "addition",
+			current:         map[string]string{"host": "localhost"},
+			desired:         map[string]string{"host": "localhost", "port": "8080"},
+			expectedApplied: map[string]string{"port": "8080"},
+		},
+		{
+			name:            "change",
+			current:         map[string]string{"host": "localhost", "port": "8080"},
+			desired:         map[string]string{"host": "example.com", "port": "8080"},
+			expectedApplied: map[string]string{"host": "example.com"},
+		},
+		{
+			name:            "removal",
+			current:         map[string]string{"host": "localhost", "debug": "true", "trace": "1"},
+			desired:         map[string]string{"host": "localhost"},
+			expectedApplied: map[string]string{},
+			expectedRemoved: []string{"debug", "trace"},
+		},
+		{
+			name:            "no-op",
+			current:         map[string]string{"host": "localhost", "port": "8080"},
+			desired:         map[string]string{"host": "localhost", "port": "8080"},
+			expectedApplied: map[string]string{},
+		},
+		{
+			name:            "from empty",
+			current:         nil,
+			desired:         map[string]string{"host": "localhost"},
+			expectedApplied: map[string]string{"host": "localhost"},
+		},
+		{
+			name:            "to empty",
+			current:         map[string]string{"host": "localhost"},
+			desired:         nil,
+			expectedApplied: map[string]string{},
+			expectedRemoved: []string{"host"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied, removed := ApplyConfigChanges(tt.current, tt.desired)
+			if !reflect.DeepEqual(applied, tt.expectedApplied) {
+				t.Errorf("applied = %v; want %v", applied, tt.expectedApplied)
+			}
+			if !reflect.DeepEqual(removed, tt.expectedRemoved) {
+				t.Errorf("removed = %v; want %v", removed, tt.expectedRemoved)
+			}
+		})
+	}
+}
+
+func TestApproxDistinctExactBelowThreshold(t *testing.T) {
+	counter := NewApproxDistinct()
+	for i := 0; i < 3*exactThreshold; i++ {
+		counter.Add([]byte(fmt.Sprintf("item-%d", i%500)))
+	}
+	
+	if got := counter.Estimate(); got != 500 {
+		t.Errorf("Estimate() = %d; want exactly 500", got)
+	}
+	
+	if got := NewApproxDistinct().Estimate(); got != 0 {
+		t.Errorf("Estimate() on empty counter = %d; want 0", got)
+	}
+}
+
+func TestApproxDistinctWithinErrorMargin(t *testing.T) {
+	tests := []int{5000, 50000, 200000}
+	
+	for _, distinct := range tests {
+		t.Run(fmt.Sprintf("%d distinct", distinct), func(t *testing.T) {
+			counter := NewApproxDistinct()
+			for i := 0; i < 2*distinct; i++ {
+				counter.Add([]byte(fmt.Sprintf("item-%d", i%distinct)))
+			}
+			
+			estimate := float64(counter.Estimate())
+			relErr := math.Abs(estimate-float64(distinct)) / float64(distinct)
+			if relErr > 0.1 {
+				t.Errorf("Estimate() = %.0f; want within 10%% of %d (error %.1f%%)", estimate, distinct, relErr*100)
+			}
+		})
+	}
+}
+
+func TestSymmetricDifferenceAndJaccard(t *testing.T) {
+	tests := []struct {
+		name            string
+		a               []string
+		b               []string
+		expectedDiff    []string
+		expectedJaccard float64
+	}{
+		{"disjoint", []string{"a", "b"}, []string{"c", "d"}, []string{"a", "b", "c", "d"}, 0},
+		{"partial overlap", []string{"go", "generics", "tutorial"}, []string{"go", "tutorial", "beginner"}, []string{"generics", "beginner"}, 0.5},
+		{"identical", []string{"a", "b"}, []string{"b", "a"}, []string{}, 1},
+		{"duplicates ignored", []string{"a", "a", "b"}, []string{"b", "c", "c"}, []string{"a", "c"}, 1.0 / 3},
+		{"one empty", []string{"a"}, nil, []string{"a"}, 0},
+		{"both empty", nil, nil, []string{}, 1},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SymmetricDifference(tt.a, tt.b); !reflect.DeepEqual(got, tt.expectedDiff) {
+				t.Errorf("SymmetricDifference(%v, %v) = %v; want %v", tt.a, tt.b, got, tt.expectedDiff)
+			}
+			if got := Jaccard(tt.a, tt.b); math.Abs(got-tt.expectedJaccard) > 1e-9 {
+				t.Errorf("Jaccard(%v, %v) = %v; want %v", tt.a, tt.b, got, tt.expectedJaccard)
+			}
+		})
+	}
+}
+
+func TestStackEmpty(t *testing.T) {
+	var stack Stack[string]
+	
+	if v, ok := stack.Pop(); ok || v != "" {
+		t.Errorf("Pop() on empty stack = %q, %t; want \"\", false", v, ok)
+	}
+	if v, ok := stack.Peek(); ok || v != "" {
+		t.Errorf("Peek() on empty stack = %q, %t; want \"\", false", v, ok)
+	}
+	if stack.Len() != 0 {
+		t.Errorf("Len() = %d; want 0", stack.Len())
+	}
+}
+
+func TestStackInterleaved(t *testing.T) {
+	var stack Stack[int]
+	var popped []int
+	
+	stack.Push(1)
+	stack.Push(2)
+	v, _ := stack.Pop()
+	popped = append(popped, v)
+	stack.Push(3)
+	stack.Push(4)
+	if top, ok := stack.Peek(); !ok || top != 4 {
+		t.Errorf("Peek() = %d, %t; want 4, true", top, ok)
+	}
+	for stack.Len() > 0 {
+		v, _ := stack.Pop()
+		popped = append(popped, v)
+	}
+	
+	expected := []int{2, 4, 3, 1}
+	if !reflect.DeepEqual(popped, expected) {
+		t.Errorf("popped = %v; want %v", popped, expected)
+	}
+	if _, ok := stack.Pop(); ok {
+		t.Error("Pop() after draining reported ok = true")
+	}
+}
+
+func TestQueueEmpty(t *testing.T) {
+	var queue Queue[int]
+	
+	if v, ok := queue.Dequeue(); ok || v != 0 {
+		t.Errorf("Dequeue() on empty queue = %d, %t; want 0, false", v, ok)
+	}
+	
+	queue.Enqueue(1)
+	queue.Dequeue()
+	if v, ok := queue.Dequeue(); ok {
+		t.Errorf("Dequeue() after draining = %d, %t; want 0, false", v, ok)
+	}
+}
+
+func TestQueuePreservesOrder(t *testing.T) {
+	var queue Queue[int]
+	var dequeued []int
+	next := 0
+	
+	// Alternate bursts of enqueues and dequeues so the head index wraps
+	// through several compactions
+	for round := 0; round < 50; round++ {
+		for i := 0; i < 7; i++ {
+			queue.Enqueue(next)
+			next++
+		}
+		for i := 0; i < 5; i++ {
+			v, ok := queue.Dequeue()
+			if !ok {
+				t.Fatalf("Dequeue() reported empty with %d elements left", queue.Len())
+			}
+			dequeued = append(dequeued, v)
+		}
+	}
+	for queue.Len() > 0 {
+		v, _ := queue.Dequeue()
+		dequeued = append(dequeued, v)
+	}
+	
+	if len(dequeued) != next {
+		t.Fatalf("dequeued %d elements; want %d", len(dequeued), next)
+	}
+	for i, v := range dequeued {
+		if v != i {
+			t.Fatalf("dequeued[%d] = %d; want %d", i, v, i)
+		}
+	}
+}
+
+func TestQueueCompactionClearsVacatedSlots(t *testing.T) {
+	var queue Queue[*int]
+	for i := 0; i < 10; i++ {
+		v := i
+		queue.Enqueue(&v)
+	}
+	for i := 0; i < 6; i++ {
+		queue.Dequeue()  // The sixth dequeue triggers a compaction
+	}
+	
+	// Nothing past the live elements may keep a value reachable
+	backing := queue.items[:cap(queue.items)]
+	for i := len(queue.items); i < len(backing); i++ {
+		if backing[i] != nil {
+			t.Errorf("backing[%d] = %d after compaction; want nil", i, *backing[i])
+		}
+	}
+	for want := 6; want < 10; want++ {
+		if v, ok := queue.Dequeue(); !ok || *v != want {
+			t.Fatalf("Dequeue() = %v, %t; want %d, true", v, ok, want)
+		}
+	}
+}
+
+func TestSetBasics(t *testing.T) {
+	var set Set[string]  // The zero value is usable
+	
+	set.Add("go")
+	set.Add("rust")
+	set.Add("go")
+	if set.Len() != 2 {
+		t.Errorf("Len() = %d; want 2", set.Len())
+	}
+	if !set.Contains("go") || set.Contains("java") {
+		t.Errorf("Contains gave wrong answers for %v", set.Items())
+	}
+	
+	set.Remove("go")
+	set.Remove("missing")
+	if set.Contains("go") || set.Len() != 1 {
+		t.Errorf("after Remove: Items() = %v; want [rust]", set.Items())
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5)
+	
+	tests := []struct {
+		name     string
+		result   Set[int]
+		expected []int
+	}{
+		{"union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"intersection", a.Intersection(b), []int{3, 4}},
+		{"difference a-b", a.Difference(b), []int{1, 2}},
+		{"difference b-a", b.Difference(a), []int{5}},
+		{"union with empty", a.Union(Set[int]{}), []int{1, 2, 3, 4}},
+		{"intersection with empty", a.Intersection(Set[int]{}), []int{}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sortedItems(tt.result); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("%s = %v; want %v", tt.name, got, tt.expected)
+			}
+		})
+	}
+	
+	// Operands are unchanged
+	if got := sortedItems(a); !reflect.DeepEqual(got, []int{1, 2, 3, 4}) {
+		t.Errorf("a was modified: %v", got)
+	}
+	if got := sortedItems(b); !reflect.DeepEqual(got, []int{3, 4, 5}) {
+		t.Errorf("b was modified: %v", got)
+	}
+	
+	// Results are independent of their operands
+	union := a.Union(b)
+	union.Add(99)
+	if a.Contains(99) || b.Contains(99) {
+		t.Error("adding to the union modified an operand")
+	}
+}
+
+func TestOrderedMapOrder(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 10)  // Update keeps position
+	m.Delete("b")
+	m.Set("d", 4)
+	m.Set("b", 20)  // Re-insert goes to the end
+	m.Delete("missing")
+	
+	if got, expected := m.Keys(), []string{"a", "c", "d", "b"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Keys() = %v; want %v", got, expected)
+	}
+	if m.Len() != 4 {
+		t.Errorf("Len() = %d; want 4", m.Len())
+	}
+	
+	if v, ok := m.Get("a"); !ok || v != 10 {
+		t.Errorf("Get(\"a\") = %d, %t; want 10, true", v, ok)
+	}
+	if _, ok := m.Get("missing"); ok {
+		t.Error("Get(\"missing\") reported ok = true")
+	}
+	
+	var values []int
+	m.Range(func(_ string, value int) bool {
+		values = append(values, value)
+		return len(values) < 3
+	})
+	if expected := []int{10, 3, 4}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("Range stopped early with %v; want %v", values, expected)
+	}
+}
+
+func TestLinkedList(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		var l LinkedList[int]
+		if v, ok := l.PopFront(); ok || v != 0 {
+			t.Errorf("PopFront() on empty list = %d, %t; want 0, false", v, ok)
+		}
+		if got := l.ToSlice(); len(got) != 0 {
+			t.Errorf("ToSlice() = %v; want []", got)
+		}
+	})
+	
+	t.Run("push both ends", func(t *testing.T) {
+		var l LinkedList[int]
+		l.PushBack(2)
+		l.PushFront(1)
+		l.PushBack(3)
+		l.PushFront(0)
+		if got, expected := l.ToSlice(), []int{0, 1, 2, 3}; !reflect.DeepEqual(got, expected) {
+			t.Errorf("ToSlice() = %v; want %v", got, expected)
+		}
+		if l.Len() != 4 {
+			t.Errorf("Len() = %d; want 4", l.Len())
+		}
+	})
+	
+	t.Run("drain and reuse", func(t *testing.T) {
+		var l LinkedList[string]
+		l.PushBack("a")
+		l.PushBack("b")
+		for _, expected := range []string{"a", "b"} {
+			if v, ok := l.PopFront(); !ok || v != expected {
+				t.Errorf("PopFront() = %q, %t; want %q, true", v, ok, expected)
+			}
+		}
+		if _, ok := l.PopFront(); ok {
+			t.Error("PopFront() on drained list reported ok = true")
+		}
+		l.PushBack("c")  // Tail must have been reset when the list emptied
+		if got, expected := l.ToSlice(), []string{"c"}; !reflect.DeepEqual(got, expected) {
+			t.Errorf("ToSlice() after reuse = %v; want %v", got, expected)
+		}
+	})
+}
+
+func TestUserClone(t *testing.T) {
+	original := User{
+		ID:       1,
+		Username: "alice",
+		Scores:   []int{95, 87},
+		Profile:  map[string]string{"city": "New York"},
+	}
+	clone := original.Clone()
+	
+	if !reflect.DeepEqual(clone, original) {
+		t.Fatalf("Clone() = %+v; want %+v", clone, original)
+	}
+	if &clone.Scores[0] == &original.Scores[0] {
+		t.Error("Clone().Scores shares its backing array with the original")
+	}
+	if reflect.ValueOf(clone.Profile).Pointer() == reflect.ValueOf(original.Profile).Pointer() {
+		t.Error("Clone().Profile is the same map as the original")
+	}
+	
+	clone.Scores[0] = 0
+	clone.Profile["city"] = "Chicago"
+	if original.Scores[0] != 95 || original.Profile["city"] != "New York" {
+		t.Errorf("mutating the clone changed the original: %+v", original)
+	}
+	
+	empty := User{Username: "bob"}.Clone()
+	if empty.Scores != nil || empty.Profile != nil {
+		t.Errorf("Clone() of nil fields = %#v, %#v; want nil, nil", empty.Scores, empty.Profile)
+	}
+}
+
+func TestDeleteAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		index    int
+		expected []int
+	}{
+		{"first", 0, []int{2, 3, 4}},
+		{"middle", 2, []int{1, 2, 4}},
+		{"last", 3, []int{1, 2, 3}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DeleteAt([]int{1, 2, 3, 4}, tt.index)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("DeleteAt([1 2 3 4], %d) = %v; want %v", tt.index, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDeleteAtClearsTail(t *testing.T) {
+	alice, bob, carol := &Person{Name: "Alice"}, &Person{Name: "Bob"}, &Person{Name: "Carol"}
+	people := []*Person{alice, bob, carol}
+	
+	result := DeleteAt(people, 1)
+	if !reflect.DeepEqual(result, []*Person{alice, carol}) {
+		t.Errorf("DeleteAt(people, 1) = %v; want [Alice Carol]", result)
+	}
+	if people[2] != nil {
+		t.Errorf("freed slot still holds %+v; want nil so it can be collected", people[2])
+	}
+}
+
+func TestDeleteAtOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("DeleteAt with index out of range did not panic")
+		}
+	}()
+	DeleteAt([]int{1, 2}, 2)
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int
+		size     int
+		expected [][]int
+	}{
+		{"evenly divisible", []int{1, 2, 3, 4, 5, 6}, 2, [][]int{{1, 2}, {3, 4}, {5, 6}}},
+		{"remainder chunk", []int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"size larger than input", []int{1, 2}, 5, [][]int{{1, 2}}},
+		{"empty input", []int{}, 3, nil},
+		{"nil input", nil, 3, nil},
+		{"zero size", []int{1, 2, 3}, 0, [][]int{{1, 2, 3}}},
+		{"negative size", []int{1, 2, 3}, -1, [][]int{{1, 2, 3}}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Chunk(tt.input, tt.size)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Chunk(%v, %d) = %v; want %v", tt.input, tt.size, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChunkAppendDoesNotOverwrite(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+	chunks := Chunk(input, 2)
+	_ = append(chunks[0], 99)
+	if !reflect.DeepEqual(input, []int{1, 2, 3, 4}) {
+		t.Errorf("appending to a chunk changed the input to %v", input)
+	}
+}
+
+func TestWalkNestedMap(t *testing.T) {
+	m := map[string]map[string]int{
+		"vegetables": {"lettuce": 2, "carrot": 10},
+		"fruits":     {"banana": 3, "apple": 5},
+		"empty":      {},
+	}
+	
+	var visited []string
+	total := 0
+	WalkNestedMap(m, func(outer, inner string, value int) {
+		visited = append(visited, fmt.Sprintf("%s/%s=%d", outer, inner, value))
+		total += value
+	})
+	
+	expected := []string{"fruits/apple=5", "fruits/banana=3", "vegetables/carrot=10", "vegetables/lettuce=2"}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Errorf("visit order = %v; want %v", visited, expected)
+	}
+	if total != 20 {
+		t.Errorf("total = %d; want 20", total)
+	}
+	
+	WalkNestedMap(nil, func(outer, inner string, value int) {
+		t.Errorf("visit called for nil map with %s/%s", outer, inner)
+	})
+}
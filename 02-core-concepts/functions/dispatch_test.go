@@ -0,0 +1,108 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// These benchmarks compare three ways of applying the same transformation
+// to a large slice:
+//   - the generic Map, where the function type is known at compile time
+//   - a Processor interface, where every call goes through dynamic dispatch
+//   - a type switch over interface{} values, which also boxes every element
+//
+// Run with: go test -bench=Dispatch -benchmem
+
+const dispatchSize = 100000
+
+// dispatchSink keeps results alive so the compiler can't drop the work
+var dispatchSink []int
+
+func double(x int) int {
+	return x * 2
+}
+
+// processWithInterface applies p to every element through the Processor interface
+func processWithInterface(items []int, p Processor) []int {
+	result := make([]int, len(items))
+	for i, item := range items {
+		result[i] = p.Process(item)
+	}
+	return result
+}
+
+// processWithTypeSwitch doubles every int found in values, ignoring other types
+func processWithTypeSwitch(values []interface{}) []int {
+	result := make([]int, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case int:
+			result = append(result, double(v))
+		case float64:
+			result = append(result, double(int(v)))
+		}
+	}
+	return result
+}
+
+func generateInts(size int) []int {
+	data := make([]int, size)
+	for i := range data {
+		data[i] = i
+	}
+	return data
+}
+
+func boxInts(items []int) []interface{} {
+	boxed := make([]interface{}, len(items))
+	for i, item := range items {
+		boxed[i] = item
+	}
+	return boxed
+}
+
+func TestDispatchApproachesAgree(t *testing.T) {
+	data := generateInts(1000)
+	
+	generic := Map(data, double)
+	viaInterface := processWithInterface(data, FuncProcessor(double))
+	viaTypeSwitch := processWithTypeSwitch(boxInts(data))
+	
+	if !reflect.DeepEqual(generic, viaInterface) {
+		t.Error("Map and Processor interface produced different results")
+	}
+	if !reflect.DeepEqual(generic, viaTypeSwitch) {
+		t.Error("Map and type switch produced different results")
+	}
+}
+
+func BenchmarkDispatchGenericMap(b *testing.B) {
+	data := generateInts(dispatchSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	
+	for i := 0; i < b.N; i++ {
+		dispatchSink = Map(data, double)
+	}
+}
+
+func BenchmarkDispatchInterface(b *testing.B) {
+	data := generateInts(dispatchSize)
+	var processor Processor = FuncProcessor(double)
+	b.ReportAllocs()
+	b.ResetTimer()
+	
+	for i := 0; i < b.N; i++ {
+		dispatchSink = processWithInterface(data, processor)
+	}
+}
+
+func BenchmarkDispatchTypeSwitch(b *testing.B) {
+	data := boxInts(generateInts(dispatchSize))
+	b.ReportAllocs()
+	b.ResetTimer()
+	
+	for i := 0; i < b.N; i++ {
+		dispatchSink = processWithTypeSwitch(data)
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -112,8 +114,16 @@ func demonstrateReturnValues() {
 	fmt.Printf("   square(5) = %d\n", squared)
 	
 	// Multiple return values
-	min, max := getMinMax([]int{3, 1, 4, 1, 5, 9, 2, 6})
-	fmt.Printf("   getMinMax([3,1,4,1,5,9,2,6]) = min: %d, max: %d\n", min, max)
+	min, max, err := getMinMax([]int{3, 1, 4, 1, 5, 9, 2, 6})
+	if err != nil {
+		fmt.Printf("   Error: %v\n", err)
+	} else {
+		fmt.Printf("   getMinMax([3,1,4,1,5,9,2,6]) = min: %d, max: %d\n", min, max)
+	}
+	
+	if _, _, err := getMinMax([]int{}); err != nil {
+		fmt.Printf("   getMinMax([]) error: %v\n", err)
+	}
 	
 	// Named return values
 	result, err := divideNamed(20, 4)
@@ -151,6 +161,10 @@ func demonstrateVariadicFunctions() {
 	result4 := sum(numbers...)
 	fmt.Printf("   sum([]int{1,2,3,4,5}...) = %d\n", result4)
 	
+	// Generic variadic function
+	prices := []float64{9.99, 4.50, 12.25}
+	fmt.Printf("   SumNumbers(prices...) = %.2f\n", SumNumbers(prices...))
+	
 	// Variadic function with different types
 	fmt.Println("   printValues(1, \"hello\", true, 3.14):")
 	printValues(1, "hello", true, 3.14, []int{1, 2, 3})
@@ -188,6 +202,27 @@ func demonstrateAnonymousFunctions() {
 		return x * 2
 	})
 	fmt.Printf("   Doubled numbers: %v\n", doubled)
+	
+	// Generic Map can change the element type
+	words := []string{"go", "is", "awesome"}
+	lengths := Map(words, func(s string) int {
+		return len(s)
+	})
+	fmt.Printf("   Word lengths: %v\n", lengths)
+	
+	people := []Person{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 25}}
+	descriptions := Map(people, func(p Person) string {
+		return fmt.Sprintf("%s (%d)", p.Name, p.Age)
+	})
+	fmt.Printf("   People: %v\n", descriptions)
+	
+	// ZipWith combines two slices element by element
+	a := []int{1, 2, 3}
+	b := []int{10, 20, 30, 40}
+	pairwise := ZipWith(a, b, func(x, y int) int {
+		return x + y
+	})
+	fmt.Printf("   ZipWith(%v, %v, +) = %v\n", a, b, pairwise)
 }
 
 // demonstrateClosures shows closure usage
@@ -205,6 +240,23 @@ func demonstrateClosures() {
 	fmt.Printf("   New counter: %d\n", counter2())
 	fmt.Printf("   Original counter: %d\n", counter())
 	
+	// Counter with custom start and step
+	countdown := createCounterWithOptions(100, -5)
+	fmt.Printf("   Countdown: %d, %d, %d\n", countdown(), countdown(), countdown())
+	
+	// Thread-safe counter closure
+	safeCounter := createSafeCounter()
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			safeCounter()
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("   Safe counter after 100 goroutines, next value: %d\n", safeCounter())
+	
 	// Closure with parameters
 	double := createMultiplier(2)
 	triple := createMultiplier(3)
@@ -217,6 +269,31 @@ func demonstrateClosures() {
 	for i, f := range funcs {
 		fmt.Printf("     Function %d: %d\n", i, f())
 	}
+	
+	// Closures can cache results (memoization)
+	fmt.Println("   Memoization:")
+	slowSquare := func(x int) int {
+		time.Sleep(50 * time.Millisecond)  // Simulate expensive work
+		return x * x
+	}
+	fastSquare := Memoize(slowSquare)
+	for i := 0; i < 3; i++ {
+		start := time.Now()
+		result := fastSquare(9)
+		fmt.Printf("     fastSquare(9) = %d (took %v)\n", result, time.Since(start).Round(time.Millisecond))
+	}
+	
+	shout := MemoizeString(strings.ToUpper)
+	fmt.Printf("     shout(\"go\") = %s\n", shout("go"))
+	
+	// Lazy initialization that runs at most once
+	fmt.Println("   Once:")
+	loadConfig := Once(func() int {
+		fmt.Println("     initializing")
+		return 42
+	})
+	fmt.Printf("     loadConfig() = %d\n", loadConfig())
+	fmt.Printf("     loadConfig() = %d\n", loadConfig())
 }
 
 // demonstrateHigherOrderFunctions shows higher-order function usage
@@ -236,6 +313,53 @@ func demonstrateHigherOrderFunctions() {
 	})
 	fmt.Printf("   Numbers > 5: %v\n", greaterThan5)
 	
+	// Generic filter works on any slice type
+	people := []Person{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 17}, {Name: "Carol", Age: 42}}
+	adults := FilterSlice(people, func(p Person) bool {
+		return p.Age >= 18
+	})
+	fmt.Printf("   Adults: %+v\n", adults)
+	
+	languages := []string{"go", "rust", "gleam", "python"}
+	gWords := FilterSlice(languages, func(s string) bool {
+		return strings.HasPrefix(s, "g")
+	})
+	fmt.Printf("   Languages starting with 'g': %v\n", gWords)
+	
+	// Prefix-based selection
+	readings := []int{1, 2, 3, 10, 1}
+	below5 := func(x int) bool { return x < 5 }
+	fmt.Printf("   TakeWhile(%v, x < 5) = %v\n", readings, TakeWhile(readings, below5))
+	fmt.Printf("   DropWhile(%v, x < 5) = %v\n", readings, DropWhile(readings, below5))
+	
+	// Reduce folds a slice into a single value
+	product := Reduce(numbers, 1, func(acc, x int) int {
+		return acc * x
+	})
+	fmt.Printf("   Product of 1..10: %d\n", product)
+	
+	names := Reduce(people, "", func(acc string, p Person) string {
+		if acc == "" {
+			return p.Name
+		}
+		return acc + ", " + p.Name
+	})
+	fmt.Printf("   All names: %s\n", names)
+	
+	// Scan keeps every intermediate accumulator
+	runningSum := Scan([]int{1, 2, 3, 4}, 0, func(acc, x int) int {
+		return acc + x
+	})
+	fmt.Printf("   Running sum of [1 2 3 4]: %v\n", runningSum)
+	
+	runningMax := Scan(readings, readings[0], func(acc, x int) int {
+		if x > acc {
+			return x
+		}
+		return acc
+	})
+	fmt.Printf("   Running max of %v: %v\n", readings, runningMax)
+	
 	// Function as return value
 	validateAge := createValidator(0, 120)
 	validateScore := createValidator(0, 100)
@@ -254,6 +378,13 @@ func demonstrateHigherOrderFunctions() {
 	
 	fmt.Printf("   squareThenDouble(3) = %d\n", squareThenDouble(3))
 	fmt.Printf("   doubleThenSquare(3) = %d\n", doubleThenSquare(3))
+	
+	// Variadic composition
+	addOne := func(x int) int { return x + 1 }
+	pipeline := Pipe(addOne, double, square)
+	composed := ComposeAll(addOne, double, square)
+	fmt.Printf("   Pipe(addOne, double, square)(3) = %d\n", pipeline(3))
+	fmt.Printf("   ComposeAll(addOne, double, square)(3) = %d\n", composed(3))
 }
 
 // demonstrateMethodReceivers shows method receiver usage
@@ -284,6 +415,25 @@ func demonstrateMethodReceivers() {
 	t.method2()  // OK (Go automatically takes address)
 	p.method1()  // OK (Go automatically dereferences)
 	p.method2()  // OK
+	
+	// Pointer receivers let methods keep state between calls
+	fmt.Println("   Stopwatch:")
+	sw := NewStopwatch(time.Now)
+	sw.Start()
+	time.Sleep(20 * time.Millisecond)  // Simulate loading
+	if err := sw.Lap("load"); err != nil {
+		fmt.Printf("     Error: %v\n", err)
+	}
+	time.Sleep(30 * time.Millisecond)  // Simulate processing
+	if err := sw.Lap("process"); err != nil {
+		fmt.Printf("     Error: %v\n", err)
+	}
+	if err := sw.Stop(); err != nil {
+		fmt.Printf("     Error: %v\n", err)
+	}
+	for _, lap := range sw.Report() {
+		fmt.Printf("     %-8s %v\n", lap.Name, lap.Duration.Round(10*time.Millisecond))
+	}
 }
 
 // demonstrateFunctionTypes shows function type usage
@@ -307,6 +457,21 @@ func demonstrateFunctionTypes() {
 	
 	result3 := processor.Process(5)
 	fmt.Printf("   processor.Process(5) = %d\n", result3)
+	
+	// Partial application fixes one operand of a BinaryOp
+	subtract := func(a, b int) int { return a - b }
+	addFive := PartialLeft(add, 5)
+	tenMinus := PartialLeft(subtract, 10)
+	minusTen := PartialRight(subtract, 10)
+	fmt.Printf("   addFive(3) = %d\n", addFive(3))
+	fmt.Printf("   tenMinus(3) = %d\n", tenMinus(3))
+	fmt.Printf("   minusTen(3) = %d\n", minusTen(3))
+	
+	// Chaining processors (middleware pattern)
+	doubler := FuncProcessor(func(x int) int { return x * 2 })
+	incrementer := FuncProcessor(func(x int) int { return x + 1 })
+	chain := ChainProcessors(WithLogging(doubler), WithLogging(incrementer))
+	fmt.Printf("   chain.Process(5) = %d\n", chain.Process(5))
 }
 
 // Basic function implementations
@@ -329,9 +494,10 @@ func square(x int) int {
 	return x * x
 }
 
-func getMinMax(numbers []int) (min, max int) {
+func getMinMax(numbers []int) (min, max int, err error) {
 	if len(numbers) == 0 {
-		return 0, 0
+		err = errors.New("getMinMax: empty slice")
+		return
 	}
 	
 	min, max = numbers[0], numbers[0]
@@ -385,9 +551,42 @@ func updatePerson(p *Person, name string, age int) {
 }
 
 func sum(numbers ...int) int {
-	total := 0
-	for _, num := range numbers {
-		total += num
+	return Reduce(numbers, 0, func(total, num int) int {
+		return total + num
+	})
+}
+
+// Reduce folds items into a single value, starting from initial and
+// combining the accumulator with each element in order.
+// An empty slice returns initial unchanged.
+func Reduce[T, A any](items []T, initial A, f func(A, T) A) A {
+	acc := initial
+	for _, item := range items {
+		acc = f(acc, item)
+	}
+	return acc
+}
+
+// Scan is Reduce that records the accumulator after each element, so
+// Scan([1, 2, 3], 0, +) yields [1, 3, 6]. The initial value is not
+// included, which means the result always has len(items) elements and
+// an empty input yields an empty slice.
+func Scan[T, A any](items []T, initial A, f func(A, T) A) []A {
+	states := make([]A, 0, len(items))
+	acc := initial
+	for _, item := range items {
+		acc = f(acc, item)
+		states = append(states, acc)
+	}
+	return states
+}
+
+// SumNumbers totals any integer or floating-point values.
+// Calling it with no arguments returns the zero value of T.
+func SumNumbers[T Number](nums ...T) T {
+	var total T
+	for _, n := range nums {
+		total += n
 	}
 	return total
 }
@@ -407,21 +606,104 @@ func formatString(prefix string, values ...interface{}) string {
 }
 
 func processNumbers(numbers []int, processor func(int) int) []int {
-	result := make([]int, len(numbers))
-	for i, num := range numbers {
-		result[i] = processor(num)
+	return Map(numbers, processor)
+}
+
+// Map applies f to every element of items and returns the results.
+// Unlike processNumbers it works for any input and output types.
+func Map[T, U any](items []T, f func(T) U) []U {
+	result := make([]U, len(items))
+	for i, item := range items {
+		result[i] = f(item)
+	}
+	return result
+}
+
+// ZipWith combines as and bs element-wise using f. The result is as long
+// as the shorter input; any extra elements of the longer one are ignored.
+func ZipWith[A, B, C any](as []A, bs []B, f func(A, B) C) []C {
+	n := len(as)
+	if len(bs) < n {
+		n = len(bs)
+	}
+	
+	result := make([]C, n)
+	for i := 0; i < n; i++ {
+		result[i] = f(as[i], bs[i])
 	}
 	return result
 }
 
 func createCounter() func() int {
+	return createCounterWithOptions(0, 1)
+}
+
+// createCounterWithOptions returns a counter that adds step on every call,
+// so the first call returns start+step
+func createCounterWithOptions(start, step int) func() int {
+	count := start
+	return func() int {
+		count += step
+		return count
+	}
+}
+
+// Memoize returns a function that caches the results of f by input,
+// so f runs at most once per distinct argument.
+// The cache is not safe for concurrent use; guard it with a mutex
+// if the memoized function is shared between goroutines.
+func Memoize(f func(int) int) func(int) int {
+	cache := make(map[int]int)
+	return func(x int) int {
+		if result, ok := cache[x]; ok {
+			return result
+		}
+		result := f(x)
+		cache[x] = result
+		return result
+	}
+}
+
+// MemoizeString is Memoize for string functions. Like Memoize, it is
+// not safe for concurrent use.
+func MemoizeString(f func(string) string) func(string) string {
+	cache := make(map[string]string)
+	return func(s string) string {
+		if result, ok := cache[s]; ok {
+			return result
+		}
+		result := f(s)
+		cache[s] = result
+		return result
+	}
+}
+
+// createSafeCounter is like createCounter but guards the captured count
+// with a mutex, so it can be called from multiple goroutines
+func createSafeCounter() func() int {
+	var mu sync.Mutex
 	count := 0
 	return func() int {
+		mu.Lock()
+		defer mu.Unlock()
 		count++
 		return count
 	}
 }
 
+// Once returns a function that calls f on its first invocation and returns
+// the cached result on every later one. It is safe for concurrent use.
+func Once(f func() int) func() int {
+	var once sync.Once
+	var result int
+	return func() int {
+		once.Do(func() {
+			result = f()
+		})
+		return result
+	}
+}
+
 func createMultiplier(factor int) func(int) int {
 	return func(x int) int {
 		return x * factor
@@ -440,15 +722,45 @@ func createClosures(count int) []func() int {
 }
 
 func filter(numbers []int, predicate func(int) bool) []int {
-	var result []int
-	for _, num := range numbers {
-		if predicate(num) {
-			result = append(result, num)
+	return FilterSlice(numbers, predicate)
+}
+
+// FilterSlice returns the elements of items for which keep returns true.
+// A nil input (or no matches) yields a nil slice.
+func FilterSlice[T any](items []T, keep func(T) bool) []T {
+	var result []T
+	for _, item := range items {
+		if keep(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// TakeWhile returns the leading elements of items for which pred holds.
+// It stops at the first failure, even if later elements would pass.
+func TakeWhile[T any](items []T, pred func(T) bool) []T {
+	var result []T
+	for _, item := range items {
+		if !pred(item) {
+			break
 		}
+		result = append(result, item)
 	}
 	return result
 }
 
+// DropWhile skips the leading elements of items for which pred holds
+// and returns everything from the first failure onward.
+func DropWhile[T any](items []T, pred func(T) bool) []T {
+	for i, item := range items {
+		if !pred(item) {
+			return append([]T(nil), items[i:]...)
+		}
+	}
+	return nil
+}
+
 func createValidator(min, max int) func(int) bool {
 	return func(value int) bool {
 		return value >= min && value <= max
@@ -461,11 +773,75 @@ func compose(f, g func(int) int) func(int) int {
 	}
 }
 
+// Pipe chains funcs left-to-right: Pipe(f, g)(x) == g(f(x)).
+// With no functions it returns the identity function.
+func Pipe(funcs ...func(int) int) func(int) int {
+	return func(x int) int {
+		for _, f := range funcs {
+			x = f(x)
+		}
+		return x
+	}
+}
+
+// ComposeAll chains funcs right-to-left like compose: ComposeAll(f, g)(x) == f(g(x)).
+// With no functions it returns the identity function.
+func ComposeAll(funcs ...func(int) int) func(int) int {
+	return func(x int) int {
+		for i := len(funcs) - 1; i >= 0; i-- {
+			x = funcs[i](x)
+		}
+		return x
+	}
+}
+
 func applyOperation(a, b int, op BinaryOp) int {
 	return op(a, b)
 }
 
+// PartialLeft fixes the first operand of op: PartialLeft(op, a)(b) == op(a, b)
+func PartialLeft(op BinaryOp, a int) func(int) int {
+	return func(b int) int {
+		return op(a, b)
+	}
+}
+
+// PartialRight fixes the second operand of op: PartialRight(op, b)(a) == op(a, b)
+func PartialRight(op BinaryOp, b int) func(int) int {
+	return func(a int) int {
+		return op(a, b)
+	}
+}
+
+// ChainProcessors returns a Processor that feeds the output of each
+// processor into the next, in order. An empty chain is the identity.
+func ChainProcessors(procs ...Processor) Processor {
+	return FuncProcessor(func(x int) int {
+		for _, p := range procs {
+			x = p.Process(x)
+		}
+		return x
+	})
+}
+
+// WithLogging decorates p so every call prints its input and output
+func WithLogging(p Processor) Processor {
+	return FuncProcessor(func(x int) int {
+		result := p.Process(x)
+		fmt.Printf("     Process(%d) = %d\n", x, result)
+		return result
+	})
+}
+
 // Type definitions
+
+// Number is satisfied by every built-in integer and floating-point type
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
 type Person struct {
 	Name string
 	Age  int
@@ -478,6 +854,30 @@ type Rectangle struct {
 
 type T struct{}
 
+// Lap is a named duration recorded by a Stopwatch
+type Lap struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Errors returned by Stopwatch when Lap or Stop is called out of order
+var (
+	ErrStopwatchNotStarted = errors.New("stopwatch: not started")
+	ErrStopwatchNotRunning = errors.New("stopwatch: not running")
+)
+
+// Stopwatch times named phases of work. The clock is injected so tests
+// can control time instead of sleeping.
+type Stopwatch struct {
+	now     func() time.Time
+	start   time.Time
+	last    time.Time
+	laps    []Lap
+	running bool
+	total   time.Duration
+	stopped bool
+}
+
 type BinaryOp func(int, int) int
 type Processor interface {
 	Process(int) int
@@ -505,6 +905,71 @@ func (r *Rectangle) ScaleInPlace(factor float64) {
 	r.Height *= factor
 }
 
+// NewStopwatch creates a stopwatch that reads time from now.
+// A nil clock defaults to time.Now.
+func NewStopwatch(now func() time.Time) *Stopwatch {
+	if now == nil {
+		now = time.Now
+	}
+	return &Stopwatch{now: now}
+}
+
+// Start begins timing, discarding any previous laps
+func (s *Stopwatch) Start() {
+	s.start = s.now()
+	s.last = s.start
+	s.laps = nil
+	s.total = 0
+	s.running = true
+	s.stopped = false
+}
+
+// Lap records the time elapsed since Start or the previous lap
+func (s *Stopwatch) Lap(name string) error {
+	if err := s.checkRunning(); err != nil {
+		return err
+	}
+	now := s.now()
+	s.laps = append(s.laps, Lap{Name: name, Duration: now.Sub(s.last)})
+	s.last = now
+	return nil
+}
+
+// Stop ends timing and records the total elapsed time
+func (s *Stopwatch) Stop() error {
+	if err := s.checkRunning(); err != nil {
+		return err
+	}
+	s.total = s.now().Sub(s.start)
+	s.running = false
+	s.stopped = true
+	return nil
+}
+
+// checkRunning reports why the stopwatch can't take a reading, if it can't:
+// either it was never started, or it has already been stopped
+func (s *Stopwatch) checkRunning() error {
+	switch {
+	case s.running:
+		return nil
+	case s.stopped:
+		return ErrStopwatchNotRunning
+	default:
+		return ErrStopwatchNotStarted
+	}
+}
+
+// Report returns the recorded laps. Once stopped, a final "total" lap
+// with the time from Start to Stop is included.
+func (s *Stopwatch) Report() []Lap {
+	report := make([]Lap, len(s.laps), len(s.laps)+1)
+	copy(report, s.laps)
+	if s.stopped {
+		report = append(report, Lap{Name: "total", Duration: s.total})
+	}
+	return report
+}
+
 func (T) method1() {
 	fmt.Println("     method1 called")
 }
@@ -0,0 +1,537 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMap(t *testing.T) {
+	t.Run("string to int", func(t *testing.T) {
+		result := Map([]string{"go", "is", "awesome"}, func(s string) int {
+			return len(s)
+		})
+		expected := []int{2, 2, 7}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Map() = %v; want %v", result, expected)
+		}
+	})
+	
+	t.Run("struct to string", func(t *testing.T) {
+		people := []Person{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 25}}
+		result := Map(people, func(p Person) string {
+			return p.Name
+		})
+		expected := []string{"Alice", "Bob"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Map() = %v; want %v", result, expected)
+		}
+	})
+	
+	t.Run("empty slice", func(t *testing.T) {
+		result := Map([]int{}, func(x int) string {
+			t.Error("f should not be called for an empty slice")
+			return ""
+		})
+		if len(result) != 0 {
+			t.Errorf("Map([]) = %v; want empty", result)
+		}
+	})
+}
+
+func TestProcessNumbersUsesMap(t *testing.T) {
+	result := processNumbers([]int{1, 2, 3}, func(x int) int {
+		return x * x
+	})
+	expected := []int{1, 4, 9}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("processNumbers() = %v; want %v", result, expected)
+	}
+}
+
+func TestFilterSlice(t *testing.T) {
+	isEven := func(x int) bool { return x%2 == 0 }
+	
+	tests := []struct {
+		name     string
+		input    []int
+		expected []int
+	}{
+		{"nil input", nil, nil},
+		{"empty input", []int{}, nil},
+		{"no matches", []int{1, 3, 5}, nil},
+		{"some matches", []int{1, 2, 3, 4}, []int{2, 4}},
+		{"all match", []int{2, 4}, []int{2, 4}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FilterSlice(tt.input, isEven)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("FilterSlice(%v) = %#v; want %#v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterSliceTypes(t *testing.T) {
+	people := []Person{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 17}}
+	adults := FilterSlice(people, func(p Person) bool { return p.Age >= 18 })
+	if !reflect.DeepEqual(adults, []Person{{Name: "Alice", Age: 30}}) {
+		t.Errorf("FilterSlice(people) = %+v; want only Alice", adults)
+	}
+	
+	words := FilterSlice([]string{"go", "rust", "gleam"}, func(s string) bool {
+		return strings.HasPrefix(s, "g")
+	})
+	if !reflect.DeepEqual(words, []string{"go", "gleam"}) {
+		t.Errorf("FilterSlice(words) = %v; want [go gleam]", words)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	product := Reduce([]int{1, 2, 3, 4}, 1, func(acc, x int) int { return acc * x })
+	if product != 24 {
+		t.Errorf("Reduce(product) = %d; want 24", product)
+	}
+	
+	people := []Person{{Name: "Alice"}, {Name: "Bob"}}
+	names := Reduce(people, "", func(acc string, p Person) string { return acc + p.Name })
+	if names != "AliceBob" {
+		t.Errorf("Reduce(names) = %q; want %q", names, "AliceBob")
+	}
+}
+
+func TestReduceEmptyReturnsInitial(t *testing.T) {
+	result := Reduce([]int{}, 42, func(acc, x int) int {
+		t.Error("f should not be called for an empty slice")
+		return acc + x
+	})
+	if result != 42 {
+		t.Errorf("Reduce(empty, 42) = %d; want 42", result)
+	}
+	
+	if sum() != 0 {
+		t.Errorf("sum() = %d; want 0", sum())
+	}
+}
+
+func TestPipeAndComposeAll(t *testing.T) {
+	addOne := func(x int) int { return x + 1 }
+	double := func(x int) int { return x * 2 }
+	square := func(x int) int { return x * x }
+	
+	tests := []struct {
+		name     string
+		fn       func(int) int
+		input    int
+		expected int
+	}{
+		{"pipe three stages", Pipe(addOne, double, square), 3, 64},
+		{"compose three stages", ComposeAll(addOne, double, square), 3, 19},
+		{"pipe single", Pipe(double), 5, 10},
+		{"compose matches compose", ComposeAll(double, square), 3, compose(double, square)(3)},
+		{"pipe identity", Pipe(), 7, 7},
+		{"compose identity", ComposeAll(), 7, 7},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.fn(tt.input); result != tt.expected {
+				t.Errorf("f(%d) = %d; want %d", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMemoizeCallsOncePerInput(t *testing.T) {
+	calls := make(map[int]int)
+	memoized := Memoize(func(x int) int {
+		calls[x]++
+		return x * x
+	})
+	
+	for _, x := range []int{2, 3, 2, 2, 3, 4} {
+		if result := memoized(x); result != x*x {
+			t.Errorf("memoized(%d) = %d; want %d", x, result, x*x)
+		}
+	}
+	
+	for x, n := range calls {
+		if n != 1 {
+			t.Errorf("f(%d) called %d times; want 1", x, n)
+		}
+	}
+	if len(calls) != 3 {
+		t.Errorf("f called for %d distinct inputs; want 3", len(calls))
+	}
+}
+
+func TestMemoizeString(t *testing.T) {
+	calls := 0
+	memoized := MemoizeString(func(s string) string {
+		calls++
+		return strings.ToUpper(s)
+	})
+	
+	memoized("go")
+	memoized("go")
+	if result := memoized("go"); result != "GO" {
+		t.Errorf("memoized(\"go\") = %q; want \"GO\"", result)
+	}
+	if calls != 1 {
+		t.Errorf("f called %d times; want 1", calls)
+	}
+}
+
+func TestCreateCounterWithOptions(t *testing.T) {
+	t.Run("independent counters", func(t *testing.T) {
+		a := createCounterWithOptions(0, 1)
+		b := createCounterWithOptions(0, 1)
+		
+		a()
+		a()
+		if got := a(); got != 3 {
+			t.Errorf("a() = %d; want 3", got)
+		}
+		if got := b(); got != 1 {
+			t.Errorf("b() = %d; want 1", got)
+		}
+	})
+	
+	t.Run("negative step counts down", func(t *testing.T) {
+		countdown := createCounterWithOptions(100, -5)
+		expected := []int{95, 90, 85}
+		for _, want := range expected {
+			if got := countdown(); got != want {
+				t.Errorf("countdown() = %d; want %d", got, want)
+			}
+		}
+	})
+	
+	t.Run("createCounter starts at one", func(t *testing.T) {
+		counter := createCounter()
+		if got := counter(); got != 1 {
+			t.Errorf("counter() = %d; want 1", got)
+		}
+	})
+}
+
+// fakeClock returns a clock that advances by the given steps on each call
+func fakeClock(steps ...time.Duration) func() time.Time {
+	current := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	i := 0
+	return func() time.Time {
+		if i > 0 && i <= len(steps) {
+			current = current.Add(steps[i-1])
+		}
+		i++
+		return current
+	}
+}
+
+func TestStopwatchReport(t *testing.T) {
+	// Start at 0, lap after 2s, lap after 3s more, stop 1s later
+	sw := NewStopwatch(fakeClock(2*time.Second, 3*time.Second, time.Second))
+	
+	sw.Start()
+	if err := sw.Lap("load"); err != nil {
+		t.Fatalf("Lap() returned error: %v", err)
+	}
+	if err := sw.Lap("process"); err != nil {
+		t.Fatalf("Lap() returned error: %v", err)
+	}
+	if err := sw.Stop(); err != nil {
+		t.Fatalf("Stop() returned error: %v", err)
+	}
+	
+	expected := []Lap{
+		{Name: "load", Duration: 2 * time.Second},
+		{Name: "process", Duration: 3 * time.Second},
+		{Name: "total", Duration: 6 * time.Second},
+	}
+	if report := sw.Report(); !reflect.DeepEqual(report, expected) {
+		t.Errorf("Report() = %v; want %v", report, expected)
+	}
+}
+
+func TestStopwatchLapBeforeStart(t *testing.T) {
+	sw := NewStopwatch(fakeClock())
+	if err := sw.Lap("early"); !errors.Is(err, ErrStopwatchNotStarted) {
+		t.Errorf("Lap() before Start = %v; want %v", err, ErrStopwatchNotStarted)
+	}
+	if err := sw.Stop(); !errors.Is(err, ErrStopwatchNotStarted) {
+		t.Errorf("Stop() before Start = %v; want %v", err, ErrStopwatchNotStarted)
+	}
+	if report := sw.Report(); len(report) != 0 {
+		t.Errorf("Report() = %v; want empty", report)
+	}
+}
+
+func TestStopwatchAfterStop(t *testing.T) {
+	sw := NewStopwatch(fakeClock(time.Second, time.Second))
+	sw.Start()
+	if err := sw.Stop(); err != nil {
+		t.Fatalf("Stop() returned error: %v", err)
+	}
+	
+	if err := sw.Lap("late"); !errors.Is(err, ErrStopwatchNotRunning) {
+		t.Errorf("Lap() after Stop = %v; want %v", err, ErrStopwatchNotRunning)
+	}
+	if err := sw.Stop(); !errors.Is(err, ErrStopwatchNotRunning) {
+		t.Errorf("Stop() after Stop = %v; want %v", err, ErrStopwatchNotRunning)
+	}
+	expected := []Lap{{Name: "total", Duration: time.Second}}
+	if report := sw.Report(); !reflect.DeepEqual(report, expected) {
+		t.Errorf("Report() = %v; want %v", report, expected)
+	}
+}
+
+func TestCreateSafeCounterConcurrent(t *testing.T) {
+	const goroutines = 100
+	const callsPerGoroutine = 50
+	
+	counter := createSafeCounter()
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < callsPerGoroutine; j++ {
+				counter()
+			}
+		}()
+	}
+	wg.Wait()
+	
+	expected := goroutines*callsPerGoroutine + 1
+	if got := counter(); got != expected {
+		t.Errorf("counter() = %d; want %d", got, expected)
+	}
+}
+
+func TestTakeWhileAndDropWhile(t *testing.T) {
+	below5 := func(x int) bool { return x < 5 }
+	
+	tests := []struct {
+		name         string
+		input        []int
+		expectedTake []int
+		expectedDrop []int
+	}{
+		{"stops at first failure", []int{1, 2, 3, 10, 1}, []int{1, 2, 3}, []int{10, 1}},
+		{"first element fails", []int{7, 1, 2}, nil, []int{7, 1, 2}},
+		{"all pass", []int{1, 2, 3}, []int{1, 2, 3}, nil},
+		{"empty", nil, nil, nil},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TakeWhile(tt.input, below5); !reflect.DeepEqual(got, tt.expectedTake) {
+				t.Errorf("TakeWhile(%v) = %v; want %v", tt.input, got, tt.expectedTake)
+			}
+			if got := DropWhile(tt.input, below5); !reflect.DeepEqual(got, tt.expectedDrop) {
+				t.Errorf("DropWhile(%v) = %v; want %v", tt.input, got, tt.expectedDrop)
+			}
+		})
+	}
+}
+
+func TestGetMinMax(t *testing.T) {
+	min, max, err := getMinMax([]int{-3, 7, 7})
+	if err != nil {
+		t.Fatalf("getMinMax() returned error: %v", err)
+	}
+	if min != -3 || max != 7 {
+		t.Errorf("getMinMax([-3 7 7]) = (%d, %d); want (-3, 7)", min, max)
+	}
+	
+	if _, _, err := getMinMax([]int{}); err == nil {
+		t.Error("getMinMax([]) should return error")
+	}
+}
+
+func TestSumNumbers(t *testing.T) {
+	if got := SumNumbers(1.5, 2.25, 0.25); got != 4.0 {
+		t.Errorf("SumNumbers(1.5, 2.25, 0.25) = %v; want 4", got)
+	}
+	
+	prices := []float32{0.5, 0.5}
+	if got := SumNumbers(prices...); got != 1 {
+		t.Errorf("SumNumbers(prices...) = %v; want 1", got)
+	}
+	
+	if got := SumNumbers[int64](); got != 0 {
+		t.Errorf("SumNumbers[int64]() = %v; want 0", got)
+	}
+	if got := SumNumbers[float64](); got != 0 {
+		t.Errorf("SumNumbers[float64]() = %v; want 0", got)
+	}
+}
+
+func TestPartialApplication(t *testing.T) {
+	ops := map[string]BinaryOp{
+		"add":      add,
+		"multiply": multiply,
+		"subtract": func(a, b int) int { return a - b },
+	}
+	pairs := [][2]int{{0, 0}, {5, 3}, {-4, 7}, {10, -2}}
+	
+	for name, op := range ops {
+		for _, pair := range pairs {
+			a, b := pair[0], pair[1]
+			want := op(a, b)
+			if got := PartialLeft(op, a)(b); got != want {
+				t.Errorf("PartialLeft(%s, %d)(%d) = %d; want %d", name, a, b, got, want)
+			}
+			if got := PartialRight(op, b)(a); got != want {
+				t.Errorf("PartialRight(%s, %d)(%d) = %d; want %d", name, b, a, got, want)
+			}
+		}
+	}
+}
+
+func TestChainProcessors(t *testing.T) {
+	doubler := FuncProcessor(func(x int) int { return x * 2 })
+	incrementer := FuncProcessor(func(x int) int { return x + 1 })
+	
+	tests := []struct {
+		name     string
+		chain    Processor
+		input    int
+		expected int
+	}{
+		{"double then increment", ChainProcessors(doubler, incrementer), 5, 11},
+		{"increment then double", ChainProcessors(incrementer, doubler), 5, 12},
+		{"single processor", ChainProcessors(doubler), 5, 10},
+		{"empty chain is identity", ChainProcessors(), 5, 5},
+		{"nested chains", ChainProcessors(ChainProcessors(doubler, doubler), incrementer), 1, 5},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.chain.Process(tt.input); got != tt.expected {
+				t.Errorf("Process(%d) = %d; want %d", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithLoggingPreservesResult(t *testing.T) {
+	doubler := FuncProcessor(func(x int) int { return x * 2 })
+	if got := WithLogging(doubler).Process(4); got != 8 {
+		t.Errorf("WithLogging(doubler).Process(4) = %d; want 8", got)
+	}
+}
+
+func TestOnceRunsExactlyOnce(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	get := Once(func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return 7
+	})
+	
+	var wg sync.WaitGroup
+	results := make([]int, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = get()
+		}(i)
+	}
+	wg.Wait()
+	
+	if calls != 1 {
+		t.Errorf("f ran %d times; want 1", calls)
+	}
+	for i, r := range results {
+		if r != 7 {
+			t.Errorf("results[%d] = %d; want 7", i, r)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	add := func(acc, x int) int { return acc + x }
+	mul := func(acc, x int) int { return acc * x }
+	
+	tests := []struct {
+		name     string
+		input    []int
+		initial  int
+		f        func(int, int) int
+		expected []int
+	}{
+		{"running sum", []int{1, 2, 3}, 0, add, []int{1, 3, 6}},
+		{"running sum with offset", []int{1, 2, 3}, 10, add, []int{11, 13, 16}},
+		{"running product", []int{1, 2, 3, 4}, 1, mul, []int{1, 2, 6, 24}},
+		{"empty input", []int{}, 5, add, []int{}},
+		{"nil input", nil, 5, add, []int{}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Scan(tt.input, tt.initial, tt.f)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Scan(%v, %d) = %v; want %v", tt.input, tt.initial, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+	
+	tests := []struct {
+		name     string
+		as       []int
+		bs       []int
+		expected []int
+	}{
+		{"equal length", []int{1, 2, 3}, []int{10, 20, 30}, []int{11, 22, 33}},
+		{"first shorter", []int{1, 2}, []int{10, 20, 30}, []int{11, 22}},
+		{"second shorter", []int{1, 2, 3}, []int{10}, []int{11}},
+		{"first empty", []int{}, []int{10, 20}, []int{}},
+		{"both nil", nil, nil, []int{}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asBefore := append([]int(nil), tt.as...)
+			bsBefore := append([]int(nil), tt.bs...)
+			
+			result := ZipWith(tt.as, tt.bs, add)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ZipWith(%v, %v) = %v; want %v", tt.as, tt.bs, result, tt.expected)
+			}
+			
+			if len(tt.as) > 0 && !reflect.DeepEqual(tt.as, asBefore) {
+				t.Errorf("ZipWith mutated as: %v; want %v", tt.as, asBefore)
+			}
+			if len(tt.bs) > 0 && !reflect.DeepEqual(tt.bs, bsBefore) {
+				t.Errorf("ZipWith mutated bs: %v; want %v", tt.bs, bsBefore)
+			}
+		})
+	}
+}
+
+func TestZipWithDifferentTypes(t *testing.T) {
+	names := []string{"Alice", "Bob"}
+	ages := []int{30, 25}
+	
+	result := ZipWith(names, ages, func(name string, age int) Person {
+		return Person{Name: name, Age: age}
+	})
+	
+	expected := []Person{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 25}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ZipWith(%v, %v) = %v; want %v", names, ages, result, expected)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+const mapVsLoopSize = 10000
+
+// mapSink keeps results alive so the compiler can't drop the work
+var mapSink []int
+
+// BenchmarkMapVsLoop measures what the generic Map costs over writing the
+// loop by hand. Both preallocate the result, so each should report exactly
+// one allocation per op; a difference means Map has regressed.
+//
+// Run with: go test -bench=MapVsLoop
+func BenchmarkMapVsLoop(b *testing.B) {
+	data := generateInts(mapVsLoopSize)
+	
+	b.Run("generic Map", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			mapSink = Map(data, double)
+		}
+	})
+	
+	b.Run("manual loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			result := make([]int, len(data))
+			for j, v := range data {
+				result[j] = double(v)
+			}
+			mapSink = result
+		}
+	})
+}
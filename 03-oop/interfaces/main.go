@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 )
@@ -45,12 +46,19 @@ func demonstrateBasicInterfaces() {
 	rect := Rectangle{Width: 10, Height: 5}
 	circle := Circle{Radius: 3}
 	
+	// Shapes implement fmt.Stringer
+	fmt.Printf("   Shapes: %s and %s\n", rect, circle)
 	fmt.Printf("   Rectangle area: %.2f\n", rect.Area())
 	fmt.Printf("   Circle area: %.2f\n", circle.Area())
 	
 	// Using interface
 	printShapeInfo(rect)
 	printShapeInfo(circle)
+	
+	// Scaling returns a new shape and leaves the original untouched
+	var scalable Scalable = circle
+	bigger := scalable.Scale(2)
+	fmt.Printf("   Circle scaled by 2 - Area: %.2f (original: %.2f)\n", bigger.Area(), circle.Area())
 }
 
 // demonstrateInterfaceImplementation shows interface implementation
@@ -74,6 +82,19 @@ func demonstrateInterfaceImplementation() {
 	drawable.Draw()
 	movable.Move(2, 3)
 	drawable.Draw()
+	
+	// Batch operations: []*Point doesn't convert to []Drawable or
+	// []Movable, so each interface slice is built element by element
+	fmt.Println("   Moving and redrawing several points:")
+	points := []*Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: -2, Y: 4}}
+	drawables := make([]Drawable, len(points))
+	movers := make([]Movable, len(points))
+	for i, p := range points {
+		drawables[i] = p  // *Point has Point's Draw in its method set
+		movers[i] = p
+	}
+	MoveAll(movers, 1, -1)
+	DrawAll(drawables)
 }
 
 // demonstrateEmptyInterface shows empty interface usage
@@ -113,15 +134,30 @@ func demonstrateInterfaceComposition() {
 	fmt.Printf("   File name: %s\n", file.name)
 	
 	// Demonstrate interface methods
+	n, err := writer.Write([]byte("Hello"))
+	if err == nil {
+		fmt.Printf("   Wrote %d bytes\n", n)
+	}
+	
 	data := make([]byte, 10)
-	n, err := reader.Read(data)
+	n, err = reader.Read(data)
 	if err == nil {
-		fmt.Printf("   Read %d bytes\n", n)
+		fmt.Printf("   Read %d bytes: %q\n", n, data[:n])
 	}
 	
-	n, err = writer.Write([]byte("Hello"))
+	_, err = reader.Read(data)
+	fmt.Printf("   Read after end: %v\n", err)
+	
+	// File satisfies io.Reader and io.Writer too, so the io helpers work
+	src := &File{name: "src.txt"}
+	written, err := io.WriteString(src, "copied via io.Copy")
 	if err == nil {
-		fmt.Printf("   Wrote %d bytes\n", n)
+		fmt.Printf("   io.WriteString wrote %d bytes to %s\n", written, src.name)
+	}
+	dst := &File{name: "dst.txt"}
+	copied, err := io.Copy(dst, src)
+	if err == nil {
+		fmt.Printf("   io.Copy copied %d bytes: %q\n", copied, dst.buf)
 	}
 	
 	err = closer.Close()
@@ -207,6 +243,19 @@ func demonstrateCommonPatterns() {
 	sort.Sort(numbers)
 	fmt.Printf("   After sort: %v\n", numbers)
 	
+	// Sorting shapes by area
+	shapes := ShapesByArea{
+		Rectangle{Width: 10, Height: 5},
+		Circle{Radius: 1},
+		Rectangle{Width: 2, Height: 3},
+		Circle{Radius: 3},
+	}
+	sort.Stable(shapes)
+	fmt.Println("   Shapes by area:")
+	for _, shape := range shapes {
+		fmt.Printf("     %s: %.2f\n", shape.Name(), shape.Area())
+	}
+	
 	// Custom sorting
 	people := []Person{
 		{Name: "Charlie", Age: 30},
@@ -219,6 +268,18 @@ func demonstrateCommonPatterns() {
 		return people[i].Age < people[j].Age
 	})
 	fmt.Printf("   After sort by age: %v\n", people)
+	
+	// Stable sorting keeps equal elements in their original order
+	team := []Person{
+		{Name: "Dana", Age: 30},
+		{Name: "Eli", Age: 25},
+		{Name: "Fay", Age: 30},
+		{Name: "Gus", Age: 25},
+	}
+	byAge := MergeSort(team, func(a, b Person) bool {
+		return a.Age < b.Age
+	})
+	fmt.Printf("   Stable sort by age: %v\n", byAge)
 }
 
 // demonstrateAdvancedConcepts shows advanced interface concepts
@@ -256,11 +317,34 @@ func demonstrateAdvancedConcepts() {
 	// Interface with pointer receiver
 	var i2 interface{ method2() } = p
 	i2.method2()
+	
+	// Tagged union (sum type) built on the empty interface
+	fmt.Println("   Tagged union:")
+	shapeKind := NewVariantType("Shape", "rectangle", "circle")
+	shapeHandlers := map[string]func(interface{}){
+		"rectangle": func(v interface{}) {
+			r := v.(Rectangle)
+			fmt.Printf("     Rectangle %.1fx%.1f\n", r.Width, r.Height)
+		},
+		"circle": func(v interface{}) {
+			c := v.(Circle)
+			fmt.Printf("     Circle with radius %.1f\n", c.Radius)
+		},
+	}
+	
+	rectVariant, _ := shapeKind.New("rectangle", Rectangle{Width: 2, Height: 3})
+	circleVariant, _ := shapeKind.New("circle", Circle{Radius: 1})
+	rectVariant.Match(shapeHandlers)
+	circleVariant.Match(shapeHandlers)
+	
+	if _, err := shapeKind.New("triangle", nil); err != nil {
+		fmt.Printf("     Error: %v\n", err)
+	}
 }
 
 // Helper functions
 func printShapeInfo(s Shape) {
-	fmt.Printf("   Shape info - Area: %.2f, Perimeter: %.2f\n", s.Area(), s.Perimeter())
+	fmt.Printf("   Shape info - Name: %s, Area: %.2f, Perimeter: %.2f\n", s.Name(), s.Area(), s.Perimeter())
 }
 
 func drawShape(d Drawable) {
@@ -271,6 +355,20 @@ func moveShape(m Movable, x, y float64) {
 	m.Move(x, y)
 }
 
+// DrawAll draws every shape in order
+func DrawAll(shapes []Drawable) {
+	for _, shape := range shapes {
+		shape.Draw()
+	}
+}
+
+// MoveAll moves every element by (dx, dy)
+func MoveAll(movers []Movable, dx, dy float64) {
+	for _, m := range movers {
+		m.Move(dx, dy)
+	}
+}
+
 func processValue(value interface{}) {
 	if str, ok := value.(string); ok {
 		fmt.Printf("     String: %s (length: %d)\n", str, len(str))
@@ -334,10 +432,44 @@ func validateUser(user User) error {
 	return nil
 }
 
+// MergeSort returns a sorted copy of s using a stable merge sort:
+// elements that compare equal keep their original relative order,
+// unlike sort.Sort which makes no such guarantee.
+func MergeSort[T any](s []T, less func(a, b T) bool) []T {
+	result := make([]T, len(s))
+	copy(result, s)
+	if len(result) < 2 {
+		return result
+	}
+	
+	mid := len(result) / 2
+	left := MergeSort(result[:mid], less)
+	right := MergeSort(result[mid:], less)
+	
+	i, j := 0, 0
+	for k := range result {
+		// Taking from the left on ties is what makes the sort stable
+		if j >= len(right) || (i < len(left) && !less(right[j], left[i])) {
+			result[k] = left[i]
+			i++
+		} else {
+			result[k] = right[j]
+			j++
+		}
+	}
+	return result
+}
+
+// NewVariantType declares a sum type that can hold exactly one of cases
+func NewVariantType(name string, cases ...string) *VariantType {
+	return &VariantType{name: name, cases: cases}
+}
+
 // Interface definitions
 type Shape interface {
 	Area() float64
 	Perimeter() float64
+	Name() string
 }
 
 type Drawable interface {
@@ -348,6 +480,10 @@ type Movable interface {
 	Move(x, y float64)
 }
 
+type Scalable interface {
+	Scale(factor float64) Shape
+}
+
 type Reader interface {
 	Read([]byte) (int, error)
 }
@@ -385,10 +521,18 @@ type Point struct {
 	X, Y float64
 }
 
+// File is an in-memory file: writes append to buf and reads
+// consume it from offset
 type File struct {
-	name string
+	name   string
+	buf    []byte
+	offset int
 }
 
+// File's methods match the standard library's, so it can be used anywhere
+// an io.ReadWriteCloser is expected. This line fails to compile otherwise.
+var _ io.ReadWriteCloser = (*File)(nil)
+
 type Person struct {
 	Name string
 	Age  int
@@ -404,8 +548,25 @@ type ValidationError struct {
 	Message string
 }
 
+// VariantType lists the cases a Variant of this type may hold
+type VariantType struct {
+	name  string
+	cases []string
+}
+
+// Variant holds a single value tagged with one of its type's cases
+type Variant struct {
+	kind  *VariantType
+	tag   string
+	value interface{}
+}
+
 type IntSlice []int
 
+// ShapesByArea sorts shapes by ascending area. Use sort.Stable to keep
+// shapes of equal area in their original order.
+type ShapesByArea []Shape
+
 type T struct{}
 
 // Method implementations
@@ -417,6 +578,18 @@ func (r Rectangle) Perimeter() float64 {
 	return 2 * (r.Width + r.Height)
 }
 
+func (r Rectangle) Name() string {
+	return "rectangle"
+}
+
+func (r Rectangle) String() string {
+	return fmt.Sprintf("Rectangle(%.1fx%.1f)", r.Width, r.Height)
+}
+
+func (r Rectangle) Scale(factor float64) Shape {
+	return Rectangle{Width: r.Width * factor, Height: r.Height * factor}
+}
+
 func (c Circle) Area() float64 {
 	return 3.14159 * c.Radius * c.Radius
 }
@@ -425,6 +598,18 @@ func (c Circle) Perimeter() float64 {
 	return 2 * 3.14159 * c.Radius
 }
 
+func (c Circle) Name() string {
+	return "circle"
+}
+
+func (c Circle) String() string {
+	return fmt.Sprintf("Circle(r=%.1f)", c.Radius)
+}
+
+func (c Circle) Scale(factor float64) Shape {
+	return Circle{Radius: c.Radius * factor}
+}
+
 func (p Point) Draw() {
 	fmt.Printf("     Drawing point at (%.2f, %.2f)\n", p.X, p.Y)
 }
@@ -434,13 +619,23 @@ func (p *Point) Move(x, y float64) {
 	p.Y += y
 }
 
+// Read copies unread bytes into data and returns io.EOF once
+// everything written so far has been consumed
 func (f *File) Read(data []byte) (int, error) {
-	// Simulate reading
-	return len(data), nil
+	if len(data) == 0 {
+		return 0, nil
+	}
+	if f.offset >= len(f.buf) {
+		return 0, io.EOF
+	}
+	n := copy(data, f.buf[f.offset:])
+	f.offset += n
+	return n, nil
 }
 
+// Write appends data to the end of the file
 func (f *File) Write(data []byte) (int, error) {
-	// Simulate writing
+	f.buf = append(f.buf, data...)
 	return len(data), nil
 }
 
@@ -469,6 +664,64 @@ func (s IntSlice) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
 
+func (s ShapesByArea) Len() int {
+	return len(s)
+}
+
+func (s ShapesByArea) Less(i, j int) bool {
+	return s[i].Area() < s[j].Area()
+}
+
+func (s ShapesByArea) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// New creates a Variant holding value under the given case.
+// It returns an error if the case was not registered with the type.
+func (vt *VariantType) New(caseName string, value interface{}) (Variant, error) {
+	for _, c := range vt.cases {
+		if c == caseName {
+			return Variant{kind: vt, tag: caseName, value: value}, nil
+		}
+	}
+	return Variant{}, fmt.Errorf("%s has no case %q (cases: %s)",
+		vt.name, caseName, strings.Join(vt.cases, ", "))
+}
+
+// Tag returns the name of the case the variant holds
+func (v Variant) Tag() string {
+	return v.tag
+}
+
+// Value returns the value the variant holds
+func (v Variant) Value() interface{} {
+	return v.value
+}
+
+// Match calls the handler for the variant's case. Matching is exhaustive:
+// it panics, naming the missing cases, unless every case of the variant's
+// type has a handler, so forgetting a case fails loudly. Matching a zero
+// Variant, such as the one VariantType.New returns with an error, also
+// panics.
+func (v Variant) Match(handlers map[string]func(interface{})) {
+	if v.kind == nil {
+		panic("Variant.Match: Match on zero Variant (was the error from VariantType.New ignored?)")
+	}
+	
+	var missing []string
+	for _, c := range v.kind.cases {
+		if _, ok := handlers[c]; !ok {
+			missing = append(missing, c)
+		}
+	}
+	if len(missing) > 0 {
+		panic(fmt.Sprintf("%s.Match: unhandled case(s): %s",
+			v.kind.name, strings.Join(missing, ", ")))
+	}
+	
+	handlers[v.tag](v.value)
+}
+
 func (T) method1() {
 	fmt.Println("     method1 called")
 }
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestMergeSortIsStable(t *testing.T) {
+	people := []Person{
+		{Name: "Dana", Age: 30},
+		{Name: "Eli", Age: 25},
+		{Name: "Fay", Age: 30},
+		{Name: "Gus", Age: 25},
+		{Name: "Hal", Age: 20},
+	}
+	original := append([]Person(nil), people...)
+	
+	result := MergeSort(people, func(a, b Person) bool {
+		return a.Age < b.Age
+	})
+	
+	expected := []Person{
+		{Name: "Hal", Age: 20},
+		{Name: "Eli", Age: 25},
+		{Name: "Gus", Age: 25},
+		{Name: "Dana", Age: 30},
+		{Name: "Fay", Age: 30},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("MergeSort() = %v; want %v", result, expected)
+	}
+	if !reflect.DeepEqual(people, original) {
+		t.Errorf("MergeSort mutated its input: %v", people)
+	}
+}
+
+func TestMergeSortMatchesSliceStable(t *testing.T) {
+	type record struct {
+		Key   int
+		Index int
+	}
+	less := func(a, b record) bool { return a.Key < b.Key }
+	rng := rand.New(rand.NewSource(1))
+	
+	for trial := 0; trial < 50; trial++ {
+		input := make([]record, rng.Intn(100))
+		for i := range input {
+			// A small key range guarantees plenty of ties
+			input[i] = record{Key: rng.Intn(10), Index: i}
+		}
+		
+		expected := make([]record, len(input))
+		copy(expected, input)
+		sort.SliceStable(expected, func(i, j int) bool {
+			return less(expected[i], expected[j])
+		})
+		
+		if result := MergeSort(input, less); !reflect.DeepEqual(result, expected) {
+			t.Fatalf("MergeSort(%v) = %v; want %v", input, result, expected)
+		}
+	}
+}
+
+func TestVariantMatch(t *testing.T) {
+	shapeKind := NewVariantType("Shape", "rectangle", "circle")
+	var matched string
+	handlers := map[string]func(interface{}){
+		"rectangle": func(v interface{}) { matched = "rectangle" },
+		"circle":    func(v interface{}) { matched = fmt.Sprintf("circle %.1f", v.(Circle).Radius) },
+	}
+	
+	v, err := shapeKind.New("circle", Circle{Radius: 2})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+	v.Match(handlers)
+	if matched != "circle 2.0" {
+		t.Errorf("Match() ran %q; want %q", matched, "circle 2.0")
+	}
+	if v.Tag() != "circle" {
+		t.Errorf("Tag() = %q; want %q", v.Tag(), "circle")
+	}
+}
+
+func TestVariantUnregisteredCase(t *testing.T) {
+	shapeKind := NewVariantType("Shape", "rectangle", "circle")
+	if _, err := shapeKind.New("triangle", nil); err == nil {
+		t.Error("New() with unregistered case should return error")
+	}
+}
+
+func TestVariantMatchPanicsOnUnhandledCase(t *testing.T) {
+	shapeKind := NewVariantType("Shape", "rectangle", "circle")
+	v, _ := shapeKind.New("rectangle", Rectangle{Width: 1, Height: 1})
+	
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("Match() with a missing handler should panic")
+		}
+		if msg := fmt.Sprint(r); !strings.Contains(msg, "circle") {
+			t.Errorf("panic message %q should name the missing case", msg)
+		}
+	}()
+	
+	v.Match(map[string]func(interface{}){
+		"rectangle": func(interface{}) {},
+	})
+}
+
+func TestVariantMatchPanicsOnZeroVariant(t *testing.T) {
+	shapeKind := NewVariantType("Shape", "rectangle", "circle")
+	v, err := shapeKind.New("triangle", nil)
+	if err == nil {
+		t.Fatal("New() with unregistered case should return error")
+	}
+	
+	defer func() {
+		r := recover()
+		if msg := fmt.Sprint(r); !strings.Contains(msg, "zero Variant") {
+			t.Errorf("Match() on zero Variant panicked with %q; want a message naming the zero Variant", msg)
+		}
+	}()
+	
+	v.Match(map[string]func(interface{}){
+		"rectangle": func(interface{}) {},
+		"circle":    func(interface{}) {},
+	})
+}
+
+func TestFileReadWrite(t *testing.T) {
+	file := &File{name: "test.txt"}
+	
+	n, err := file.Write([]byte("Hello"))
+	if err != nil || n != 5 {
+		t.Fatalf("Write(\"Hello\") = %d, %v; want 5, nil", n, err)
+	}
+	
+	data := make([]byte, 3)
+	n, err = file.Read(data)
+	if err != nil || string(data[:n]) != "Hel" {
+		t.Errorf("first Read = %q, %v; want \"Hel\", nil", data[:n], err)
+	}
+	
+	n, err = file.Read(data)
+	if err != nil || string(data[:n]) != "lo" {
+		t.Errorf("second Read = %q, %v; want \"lo\", nil", data[:n], err)
+	}
+	
+	n, err = file.Read(data)
+	if n != 0 || err != io.EOF {
+		t.Errorf("Read at end = %d, %v; want 0, io.EOF", n, err)
+	}
+}
+
+func TestFileReadAll(t *testing.T) {
+	file := &File{name: "test.txt"}
+	file.Write([]byte("Hello, "))
+	file.Write([]byte("World"))
+	
+	content, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("io.ReadAll() error = %v", err)
+	}
+	if string(content) != "Hello, World" {
+		t.Errorf("io.ReadAll() = %q; want %q", content, "Hello, World")
+	}
+}
+
+func TestShapeName(t *testing.T) {
+	tests := []struct {
+		shape    Shape
+		expected string
+	}{
+		{Rectangle{Width: 10, Height: 5}, "rectangle"},
+		{Circle{Radius: 3}, "circle"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.shape.Name(); got != tt.expected {
+				t.Errorf("%T.Name() = %q; want %q", tt.shape, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestShapesByArea(t *testing.T) {
+	shapes := ShapesByArea{
+		Rectangle{Width: 10, Height: 5},
+		Circle{Radius: 1},
+		Rectangle{Width: 2, Height: 3},
+	}
+	sort.Sort(shapes)
+	
+	expected := ShapesByArea{
+		Circle{Radius: 1},
+		Rectangle{Width: 2, Height: 3},
+		Rectangle{Width: 10, Height: 5},
+	}
+	if !reflect.DeepEqual(shapes, expected) {
+		t.Errorf("sort.Sort(ShapesByArea) = %v; want %v", shapes, expected)
+	}
+}
+
+func TestShapesByAreaEqualAreasStable(t *testing.T) {
+	// All three shapes have an area of 12
+	shapes := ShapesByArea{
+		Rectangle{Width: 4, Height: 3},
+		Rectangle{Width: 2, Height: 6},
+		Rectangle{Width: 12, Height: 1},
+		Circle{Radius: 0.5},
+	}
+	sort.Stable(shapes)
+	
+	expected := ShapesByArea{
+		Circle{Radius: 0.5},
+		Rectangle{Width: 4, Height: 3},
+		Rectangle{Width: 2, Height: 6},
+		Rectangle{Width: 12, Height: 1},
+	}
+	if !reflect.DeepEqual(shapes, expected) {
+		t.Errorf("sort.Stable(ShapesByArea) = %v; want %v", shapes, expected)
+	}
+}
+
+func TestScalable(t *testing.T) {
+	rect := Rectangle{Width: 10, Height: 5}
+	circle := Circle{Radius: 3}
+	
+	tests := []struct {
+		name         string
+		shape        Scalable
+		factor       float64
+		expectedArea float64
+	}{
+		{"rectangle", rect, 2, 200},
+		{"circle", circle, 2, Circle{Radius: 6}.Area()},
+		{"shrink", rect, 0.5, 12.5},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scaled := tt.shape.Scale(tt.factor)
+			if got := scaled.Area(); math.Abs(got-tt.expectedArea) > 1e-9 {
+				t.Errorf("Scale(%v).Area() = %v; want %v", tt.factor, got, tt.expectedArea)
+			}
+		})
+	}
+	
+	if rect != (Rectangle{Width: 10, Height: 5}) {
+		t.Errorf("rect was modified by Scale: %v", rect)
+	}
+	if circle != (Circle{Radius: 3}) {
+		t.Errorf("circle was modified by Scale: %v", circle)
+	}
+}
+
+func TestShapeString(t *testing.T) {
+	tests := []struct {
+		shape    fmt.Stringer
+		expected string
+	}{
+		{Rectangle{Width: 10, Height: 5}, "Rectangle(10.0x5.0)"},
+		{Rectangle{Width: 2.5, Height: 0}, "Rectangle(2.5x0.0)"},
+		{Circle{Radius: 3}, "Circle(r=3.0)"},
+		{Circle{Radius: 1.5}, "Circle(r=1.5)"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.shape.String(); got != tt.expected {
+				t.Errorf("String() = %q; want %q", got, tt.expected)
+			}
+			if got := fmt.Sprintf("%s", tt.shape); got != tt.expected {
+				t.Errorf("Sprintf(%%s) = %q; want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// recordingShape is a fake Drawable and Movable that logs every call
+type recordingShape struct {
+	id    string
+	calls *[]string
+}
+
+func (r recordingShape) Draw() {
+	*r.calls = append(*r.calls, r.id+".Draw")
+}
+
+func (r recordingShape) Move(x, y float64) {
+	*r.calls = append(*r.calls, fmt.Sprintf("%s.Move(%g,%g)", r.id, x, y))
+}
+
+func TestDrawAllMoveAll(t *testing.T) {
+	var calls []string
+	a := recordingShape{id: "a", calls: &calls}
+	b := recordingShape{id: "b", calls: &calls}
+	
+	MoveAll([]Movable{a, b}, 2, -3)
+	DrawAll([]Drawable{a, b})
+	
+	expected := []string{"a.Move(2,-3)", "b.Move(2,-3)", "a.Draw", "b.Draw"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("calls = %v; want %v", calls, expected)
+	}
+	
+	// Empty slices are a no-op
+	calls = nil
+	MoveAll(nil, 1, 1)
+	DrawAll(nil)
+	if len(calls) != 0 {
+		t.Errorf("calls on empty slices = %v; want none", calls)
+	}
+}
+
+func TestMoveAllPoints(t *testing.T) {
+	points := []*Point{{X: 0, Y: 0}, {X: 1, Y: 1}}
+	movers := []Movable{points[0], points[1]}
+	MoveAll(movers, 1, -1)
+	
+	if *points[0] != (Point{X: 1, Y: -1}) || *points[1] != (Point{X: 2, Y: 0}) {
+		t.Errorf("after MoveAll points = %+v, %+v; want {1 -1}, {2 0}", *points[0], *points[1])
+	}
+}
+
+func TestFileIOCopy(t *testing.T) {
+	content := strings.Repeat("interfaces compose; ", 2000)  // Larger than io.Copy's buffer
+	
+	src := &File{name: "src.txt"}
+	written, err := io.WriteString(src, content)
+	if err != nil || written != len(content) {
+		t.Fatalf("io.WriteString() = %d, %v; want %d, nil", written, err, len(content))
+	}
+	
+	dst := &File{name: "dst.txt"}
+	copied, err := io.Copy(dst, src)
+	if err != nil {
+		t.Fatalf("io.Copy() error: %v", err)
+	}
+	if copied != int64(written) {
+		t.Errorf("io.Copy() copied %d bytes; want %d", copied, written)
+	}
+	if string(dst.buf) != content {
+		t.Errorf("dst content differs from src (len %d vs %d)", len(dst.buf), len(content))
+	}
+	
+	// src is fully consumed, so a second copy moves nothing
+	if again, err := io.Copy(dst, src); err != nil || again != 0 {
+		t.Errorf("second io.Copy() = %d, %v; want 0, nil", again, err)
+	}
+}
@@ -2,9 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
 	"sync"
 	"time"
+	
+	"go-learning/pkg/idgen"
 )
 
 // This example demonstrates Go's concurrency features
@@ -181,6 +191,27 @@ func demonstrateSelect() {
 	case <-time.After(100 * time.Millisecond):
 		fmt.Println("     Timeout!")
 	}
+	
+	// The same pattern as a reusable decorator
+	fmt.Println("\n   Timeout decorator:")
+	fast := func() error { return nil }
+	slow := func() error {
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	}
+	fmt.Printf("     fast op: %v\n", WithTimeout(100*time.Millisecond, fast))
+	fmt.Printf("     slow op: %v\n", WithTimeout(50*time.Millisecond, slow))
+	
+	// The context variant lets the operation stop itself
+	err := WithTimeoutContext(context.Background(), 50*time.Millisecond, func(ctx context.Context) error {
+		select {
+		case <-time.After(200 * time.Millisecond):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()  // Gives up instead of leaking
+		}
+	})
+	fmt.Printf("     slow op with context: %v\n", err)
 }
 
 // demonstrateSynchronization shows synchronization primitives
@@ -214,9 +245,13 @@ func demonstrateSynchronization() {
 	wg.Wait()
 	fmt.Printf("     Counter value: %d\n", counter.Value())
 	
+	counter.Add(5)
+	counter.Decrement()
+	fmt.Printf("     After Add(5) and Decrement(): %d\n", counter.Value())
+	
 	// RWMutex
 	fmt.Println("\n   RWMutex:")
-	safeMap := &SafeMap{data: make(map[string]int)}
+	safeMap := &SafeMap{}
 	
 	// Multiple readers
 	for i := 0; i < 5; i++ {
@@ -236,6 +271,57 @@ func demonstrateSynchronization() {
 	
 	wg.Wait()
 	fmt.Println("     RWMutex operations completed")
+	
+	safeMap.Set("banana", 3)
+	safeMap.Set("apple", 5)
+	safeMap.Delete("key")
+	fmt.Printf("     Keys: %v (len %d)\n", safeMap.Keys(), safeMap.Len())
+	
+	// Semaphore
+	fmt.Println("\n   Semaphore (2 of 10 at a time):")
+	sem := NewSemaphore(2)
+	var semMu sync.Mutex
+	active, peak := 0, 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sem.Acquire(context.Background()); err != nil {
+				return
+			}
+			defer sem.Release()
+			
+			semMu.Lock()
+			active++
+			if active > peak {
+				peak = active
+			}
+			semMu.Unlock()
+			
+			time.Sleep(10 * time.Millisecond)
+			
+			semMu.Lock()
+			active--
+			semMu.Unlock()
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("     Peak concurrent goroutines: %d\n", peak)
+	
+	// Atomic ID generator (shared package, no mutex)
+	fmt.Println("\n   Atomic ID generator:")
+	ids := idgen.NewIDGenerator(100)
+	issued := make([]int64, 5)
+	for i := range issued {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			issued[i] = ids.Next()
+		}(i)
+	}
+	wg.Wait()
+	slices.Sort(issued)  // Goroutines finish in any order
+	fmt.Printf("     IDs issued concurrently: %v\n", issued)
 }
 
 // demonstrateCommonPatterns shows common concurrency patterns
@@ -263,6 +349,44 @@ func demonstrateCommonPatterns() {
 		<-results
 	}
 	
+	// Generic worker pool with typed results and errors
+	fmt.Println("\n   Generic worker pool:")
+	lengths, err := RunWorkerPool([]string{"go", "channels", "select"}, 2, func(word string) (int, error) {
+		return len(word), nil
+	})
+	fmt.Printf("     Word lengths (any order): %v, err=%v\n", lengths, err)
+	
+	_, err = RunWorkerPool([]int{1, 2, -3, 4}, 2, func(n int) (int, error) {
+		if n < 0 {
+			return 0, fmt.Errorf("negative input %d", n)
+		}
+		return n * n, nil
+	})
+	fmt.Printf("     With a bad job: %v\n", err)
+	
+	squaresInOrder := RunWorkerPoolOrdered([]int{5, 1, 4, 2}, 3, func(n int) int {
+		time.Sleep(time.Duration(n) * time.Millisecond)  // Larger inputs finish later
+		return n * n
+	})
+	fmt.Printf("     Ordered squares of [5 1 4 2]: %v\n", squaresInOrder)
+	
+	// Shared queue vs static partitioning with skewed task durations
+	fmt.Println("\n   Balanced dispatch:")
+	durations := []time.Duration{80, 80, 10, 10, 10, 10, 10, 10}
+	tasks := make([]func(), len(durations))
+	for i, d := range durations {
+		d := d * time.Millisecond
+		tasks[i] = func() { time.Sleep(d) }
+	}
+	
+	start := time.Now()
+	DispatchStatic(tasks, 2)
+	fmt.Printf("     Static split (2 workers): %v\n", time.Since(start).Round(10*time.Millisecond))
+	
+	start = time.Now()
+	Dispatch(tasks, 2)
+	fmt.Printf("     Shared queue (2 workers): %v\n", time.Since(start).Round(10*time.Millisecond))
+	
 	// Pipeline
 	fmt.Println("\n   Pipeline:")
 	numbers := make(chan int)
@@ -286,6 +410,42 @@ func demonstrateCommonPatterns() {
 		fmt.Printf("       %d\n", s)
 	}
 	
+	// The same pipeline from reusable stages, changing type along the way
+	fmt.Println("\n   Generic stages:")
+	squared := Stage(generate(1, 2, 3), func(n int) int { return n * n })
+	labels := Stage(squared, func(n int) string { return fmt.Sprintf("square=%d", n) })
+	for label := range labels {
+		fmt.Printf("       %s\n", label)
+	}
+	
+	// Fluent pipeline built from reusable stages
+	fmt.Println("\n   Pipeline builder:")
+	built := Start(generate(1, 2, 3, 4, 5)).
+		Then(func(n int) int { return n * n }).
+		Then(func(n int) int { return n + 1 }).
+		Run()
+	
+	fmt.Println("     Square then increment:")
+	for n := range built {
+		fmt.Printf("       %d\n", n)
+	}
+	
+	// Bounded buffers let a fast producer run ahead of a slow consumer,
+	// but only by the buffer size (backpressure)
+	fmt.Println("\n   Bounded buffer (size 2):")
+	produced, err := BufferedStage(generate(1, 2, 3, 4, 5), func(n int) int {
+		fmt.Printf("       Produced %d\n", n)
+		return n
+	}, 2)
+	if err != nil {
+		fmt.Printf("     Stage error: %v\n", err)
+		return
+	}
+	for n := range produced {
+		time.Sleep(20 * time.Millisecond)  // Slow consumer
+		fmt.Printf("       Consumed %d\n", n)
+	}
+	
 	// Fan-out/Fan-in
 	fmt.Println("\n   Fan-out/Fan-in:")
 	input := make(chan int)
@@ -308,6 +468,54 @@ func demonstrateCommonPatterns() {
 		fmt.Printf("       %d\n", result)
 	}
 	
+	// Fan-in that stops when the consumer loses interest
+	fmt.Println("\n   Cancellable fan-in:")
+	mergeCtxDemo, stopMerge := context.WithCancel(context.Background())
+	defer stopMerge()
+	endless := func(start int) <-chan int {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for n := start; ; n += 2 {
+				select {
+				case ch <- n:
+				case <-mergeCtxDemo.Done():
+					return
+				}
+			}
+		}()
+		return ch
+	}
+	merged := mergeCtx(mergeCtxDemo, endless(0), endless(1))
+	for i := 0; i < 5; i++ {
+		fmt.Printf("       %d\n", <-merged)
+	}
+	stopMerge()  // Stop early; the forwarders exit and the output is closed
+	for range merged {
+	}
+	fmt.Println("     Merged channel closed after cancel")
+	
+	// Bounded-concurrency HTTP fetching
+	fmt.Println("\n   Batch HTTP fetch:")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, "content of %s", r.URL.Path)
+	}))
+	defer server.Close()
+	
+	urls := []string{server.URL + "/a", server.URL + "/b", server.URL + "/missing"}
+	bodies, fetchErrs := FetchURLs(context.Background(), urls, 2)
+	for _, url := range urls {
+		if body, ok := bodies[url]; ok {
+			fmt.Printf("     %s -> %q\n", url[len(server.URL):], body)
+		} else {
+			fmt.Printf("     %s -> error: %v\n", url[len(server.URL):], fetchErrs[url])
+		}
+	}
+	
 	// Context for cancellation
 	fmt.Println("\n   Context for cancellation:")
 	ctx, cancel := context.WithCancel(context.Background())
@@ -323,6 +531,20 @@ func demonstrateCommonPatterns() {
 	case <-ctx.Done():
 		fmt.Println("     Operation cancelled")
 	}
+	
+	// Rate limiting with a token bucket
+	fmt.Println("\n   Rate limiting (5 ops/sec, burst 5):")
+	limiter := NewRateLimiter(5, time.Second)
+	defer limiter.Stop()
+	
+	limitStart := time.Now()
+	for i := 1; i <= 7; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			fmt.Printf("     Wait error: %v\n", err)
+			break
+		}
+		fmt.Printf("       Op %d at %v\n", i, time.Since(limitStart).Round(100*time.Millisecond))
+	}
 }
 
 // demonstrateAdvancedConcepts shows advanced concurrency concepts
@@ -354,6 +576,16 @@ func demonstrateAdvancedConcepts() {
 		fmt.Println("     Failed to send 42")
 	}
 	
+	if _, ok := nonBlockingReceive(ch2); !ok {
+		fmt.Println("     Nothing to receive from empty channel")
+	}
+	
+	buffered := make(chan int, 1)
+	buffered <- 7
+	if value, ok := nonBlockingReceive(buffered); ok {
+		fmt.Printf("     Received %d from buffered channel\n", value)
+	}
+	
 	// Channel leak prevention
 	fmt.Println("\n   Channel leak prevention:")
 	ch3 := make(chan int)
@@ -369,6 +601,75 @@ func demonstrateAdvancedConcepts() {
 	for value := range ch3 {
 		fmt.Printf("       %d\n", value)
 	}
+	
+	// Idempotent close shared by several goroutines
+	fmt.Println("\n   Safe close:")
+	closer := NewSafeCloser[int](3)
+	for i := 1; i <= 3; i++ {
+		closer.Send(i)
+	}
+	
+	var closeWg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		closeWg.Add(1)
+		go func() {
+			defer closeWg.Done()
+			closer.Close()  // Only the first call closes the channel
+		}()
+	}
+	closeWg.Wait()
+	
+	fmt.Printf("     Send after close succeeded: %t\n", closer.Send(99))
+	fmt.Print("     Received before close:")
+	for value := range closer.C() {
+		fmt.Printf(" %d", value)
+	}
+	fmt.Println()
+	
+	// Cancellable computation with progress reporting
+	fmt.Println("\n   Cancellable computation:")
+	computeCtx, computeCancel := context.WithCancel(context.Background())
+	defer computeCancel()
+	
+	err := Compute(computeCtx, 10, func(i int) error {
+		if i == 4 {
+			computeCancel()  // Simulate the caller giving up midway
+		}
+		return nil
+	}, func(done, total int) {
+		fmt.Printf("       Progress: %d/%d\n", done, total)
+	})
+	fmt.Printf("     Compute returned: %v\n", err)
+	
+	// Per-request instrumentation carried through the context
+	fmt.Println("\n   Instrumented request:")
+	reqCtx := WithRequestID(context.Background(), "req-42")
+	err = Instrument(reqCtx, "handle", func(ctx context.Context) error {
+		if err := Instrument(ctx, "loadUser", func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}); err != nil {
+			return err
+		}
+		return Instrument(ctx, "saveOrder", func(ctx context.Context) error {
+			time.Sleep(5 * time.Millisecond)
+			return fmt.Errorf("order rejected")
+		})
+	})
+	fmt.Printf("     Instrument returned: %v\n", err)
+	
+	// Debounce: a burst of calls triggers one run after things go quiet
+	fmt.Println("\n   Debounce:")
+	saved := make(chan struct{}, 1)
+	save := Debounce(50*time.Millisecond, func() {
+		fmt.Println("     Saving draft (once for the whole burst)")
+		saved <- struct{}{}
+	})
+	for i := 0; i < 5; i++ {
+		save()  // e.g. one call per keystroke
+		time.Sleep(10 * time.Millisecond)
+	}
+	<-saved
 }
 
 // Helper functions
@@ -400,6 +701,159 @@ func workerPool(id int, jobs <-chan int, results chan<- int) {
 	}
 }
 
+// RunWorkerPool applies fn to every job using the given number of worker
+// goroutines (at least one) and returns the results in no particular order.
+// The first error stops the pool: jobs that haven't started by then are
+// skipped rather than run, and the error is returned with no results.
+func RunWorkerPool[T, R any](jobs []T, workers int, fn func(T) (R, error)) ([]R, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	
+	jobCh := make(chan T)
+	go func() {
+		defer close(jobCh)
+		for _, job := range jobs {
+			select {
+			case jobCh <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		results  = make([]R, 0, len(jobs))
+		firstErr error
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				// The producer may still hand out a job after cancel, since
+				// select picks at random when both cases are ready
+				if ctx.Err() != nil {
+					continue
+				}
+				result, err := fn(job)
+				
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				} else {
+					results = append(results, result)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+// RunWorkerPoolOrdered is like RunWorkerPool for functions that can't fail,
+// but returns the results in the same order as jobs: results[i] is fn(jobs[i])
+// however the work was scheduled.
+func RunWorkerPoolOrdered[T, R any](jobs []T, workers int, fn func(T) R) []R {
+	if workers < 1 {
+		workers = 1
+	}
+	
+	type indexedJob struct {
+		index int
+		job   T
+	}
+	
+	jobCh := make(chan indexedJob, len(jobs))
+	for i, job := range jobs {
+		jobCh <- indexedJob{index: i, job: job}
+	}
+	close(jobCh)
+	
+	// Each worker writes only to the slots of its own jobs, so no lock is needed
+	results := make([]R, len(jobs))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ij := range jobCh {
+				results[ij.index] = fn(ij.job)
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Dispatch runs tasks on a pool of workers that pull from a shared queue,
+// so a worker that finishes early simply takes the next task. This keeps
+// every worker busy even when task durations vary wildly. workers below 1
+// are treated as 1, which runs the tasks serially in order.
+func Dispatch(tasks []func(), workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	
+	queue := make(chan func(), len(tasks))
+	for _, task := range tasks {
+		queue <- task
+	}
+	close(queue)
+	
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range queue {
+				task()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// DispatchStatic splits tasks into equal contiguous chunks up front, one
+// per worker. A chunk full of slow tasks holds up the whole batch, which
+// is what Dispatch avoids.
+func DispatchStatic(tasks []func(), workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	
+	chunkSize := (len(tasks) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(tasks); start += chunkSize {
+		end := start + chunkSize
+		if end > len(tasks) {
+			end = len(tasks)
+		}
+		
+		wg.Add(1)
+		go func(chunk []func()) {
+			defer wg.Done()
+			for _, task := range chunk {
+				task()
+			}
+		}(tasks[start:end])
+	}
+	wg.Wait()
+}
+
 func process(input <-chan int) <-chan int {
 	output := make(chan int)
 	go func() {
@@ -433,6 +887,87 @@ func merge(channels ...<-chan int) <-chan int {
 	return output
 }
 
+// mergeCtx is merge with cancellation: once ctx is done every forwarding
+// goroutine stops, even if its input is still open or the consumer has
+// stopped reading, and the output channel is closed.
+func mergeCtx(ctx context.Context, channels ...<-chan int) <-chan int {
+	output := make(chan int)
+	var wg sync.WaitGroup
+	
+	for _, ch := range channels {
+		wg.Add(1)
+		go func(ch <-chan int) {
+			defer wg.Done()
+			for {
+				select {
+				case n, ok := <-ch:
+					if !ok {
+						return
+					}
+					select {
+					case output <- n:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ch)
+	}
+	
+	go func() {
+		wg.Wait()
+		close(output)
+	}()
+	
+	return output
+}
+
+// generate emits numbers on a channel and closes it when done
+func generate(numbers ...int) <-chan int {
+	output := make(chan int)
+	go func() {
+		defer close(output)
+		for _, n := range numbers {
+			output <- n
+		}
+	}()
+	return output
+}
+
+// Stage applies fn to every value received from in on its own goroutine.
+// The returned channel is closed once in is closed and drained.
+func Stage[T, U any](in <-chan T, fn func(T) U) <-chan U {
+	return startStage(in, fn, 0)
+}
+
+// BufferedStage is like Stage but lets the stage run up to buffer values
+// ahead of its consumer. A buffer of 0 is a synchronous handoff, exactly
+// like Stage; a negative buffer is an error.
+func BufferedStage[T, U any](in <-chan T, fn func(T) U, buffer int) (<-chan U, error) {
+	if buffer < 0 {
+		return nil, fmt.Errorf("invalid buffer size %d: must not be negative", buffer)
+	}
+	return startStage(in, fn, buffer), nil
+}
+
+func startStage[T, U any](in <-chan T, fn func(T) U, buffer int) <-chan U {
+	output := make(chan U, buffer)
+	go func() {
+		defer close(output)
+		for v := range in {
+			output <- fn(v)
+		}
+	}()
+	return output
+}
+
+// Start begins a fluent pipeline reading from source
+func Start[T any](source <-chan T) *Pipeline[T] {
+	return &Pipeline[T]{source: source}
+}
+
 func nonBlockingSend(ch chan<- int, value int) bool {
 	select {
 	case ch <- value:
@@ -442,15 +977,324 @@ func nonBlockingSend(ch chan<- int, value int) bool {
 	}
 }
 
+// NewRateLimiter allows rate operations per the given duration. The bucket
+// starts full, so up to rate operations may run immediately; after that a
+// token is added every per/rate, but never more often than every
+// nanosecond, since a zero or negative ticker interval would panic.
+// Call Stop to release the refill goroutine.
+func NewRateLimiter(rate int, per time.Duration) *RateLimiter {
+	if rate < 1 {
+		rate = 1
+	}
+	interval := per / time.Duration(rate)
+	if interval < 1 {
+		interval = 1
+	}
+	
+	rl := &RateLimiter{
+		tokens: make(chan struct{}, rate),
+		ticker: time.NewTicker(interval),
+		done:   make(chan struct{}),
+	}
+	for i := 0; i < rate; i++ {
+		rl.tokens <- struct{}{}
+	}
+	
+	go rl.refill()
+	return rl
+}
+
+// NewSemaphore creates a semaphore that lets at most n holders in at once.
+// n < 1 is treated as 1, since a zero-slot semaphore could never be acquired.
+func NewSemaphore(n int) *Semaphore {
+	if n < 1 {
+		n = 1
+	}
+	return &Semaphore{slots: make(chan struct{}, n)}
+}
+
+// NewSafeCloser creates a SafeCloser around a new channel with the given buffer size
+func NewSafeCloser[T any](buffer int) *SafeCloser[T] {
+	return &SafeCloser[T]{
+		ch:   make(chan T, buffer),
+		done: make(chan struct{}),
+	}
+}
+
+// nonBlockingReceive returns the next value from ch if one is ready.
+// ok is false when nothing is waiting and also when ch is closed.
+func nonBlockingReceive(ch <-chan int) (value int, ok bool) {
+	select {
+	case value, ok = <-ch:
+		return value, ok
+	default:
+		return 0, false
+	}
+}
+
+// WithTimeout runs op in a new goroutine and returns its error, or a
+// timeout error wrapping context.DeadlineExceeded if op takes longer than d.
+// Go can't stop a goroutine from outside, so after a timeout op keeps running
+// in the background; if op never returns, that goroutine leaks. Prefer
+// WithTimeoutContext when op can watch for cancellation.
+func WithTimeout(d time.Duration, op func() error) error {
+	return WithTimeoutContext(context.Background(), d, func(context.Context) error {
+		return op()
+	})
+}
+
+// WithTimeoutContext is WithTimeout for operations that accept a context.
+// op receives a context that is cancelled when d elapses or ctx is done,
+// so a well-behaved op can return early and its goroutine exits.
+func WithTimeoutContext(ctx context.Context, d time.Duration, op func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	
+	done := make(chan error, 1)  // Buffered so a late op never blocks
+	go func() {
+		done <- op(ctx)
+	}()
+	
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("operation timed out after %v: %w", d, ctx.Err())
+		}
+		return ctx.Err()
+	}
+}
+
+// Debounce returns a function that delays calling f until d has passed
+// without another call. Each call resets the timer, so a rapid burst of
+// calls runs f once, d after the last one. f runs in its own goroutine.
+func Debounce(d time.Duration, f func()) func() {
+	var mu sync.Mutex
+	var timer *time.Timer
+	
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if timer == nil {
+			timer = time.AfterFunc(d, f)
+			return
+		}
+		timer.Reset(d)
+	}
+}
+
+// Compute runs step for every i in [0, total), checking ctx between steps
+// and calling progress after each completed step. For a non-zero total,
+// progress is also called with 0 before the first step, so callers hear
+// from Compute even if it stops straight away. It stops at the first step
+// error, or with ctx.Err() once the context is cancelled.
+func Compute(ctx context.Context, total int, step func(i int) error, progress func(done, total int)) error {
+	if progress != nil && total > 0 {
+		progress(0, total)
+	}
+	
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		
+		if err := step(i); err != nil {
+			return err
+		}
+		
+		if progress != nil {
+			progress(i+1, total)
+		}
+	}
+	return nil
+}
+
+// FetchURLs GETs every URL with at most concurrency requests in flight
+// (values below 1 are treated as 1). Each request is bounded by
+// fetchTimeout. Bodies of successful (2xx) responses are returned keyed by
+// URL; every other URL appears in the error map instead. Once ctx is
+// cancelled, URLs that have not started yet fail with ctx.Err().
+func FetchURLs(ctx context.Context, urls []string, concurrency int) (map[string][]byte, map[string]error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	
+	bodies := make(map[string][]byte)
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	
+	for _, url := range urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			
+			var body []byte
+			var err error
+			select {
+			case sem <- struct{}{}:
+				body, err = fetchURL(ctx, url)
+				<-sem
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+			
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[url] = err
+			} else {
+				bodies[url] = body
+			}
+		}(url)
+	}
+	
+	wg.Wait()
+	return bodies, errs
+}
+
+// fetchTimeout bounds each individual request made by FetchURLs
+const fetchTimeout = 5 * time.Second
+
+func fetchURL(ctx context.Context, url string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// instrumentLogger receives the start/end lines written by Instrument
+var instrumentLogger = log.New(os.Stdout, "     ", 0)
+
+// WithRequestID returns a copy of ctx carrying id, which Instrument
+// includes in every line it logs
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the request ID stored in ctx, or "-" if there is none
+func RequestID(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey).(string); ok {
+		return id
+	}
+	return "-"
+}
+
+// Instrument logs the start and end of fn, tagged with the request ID from
+// ctx, along with the elapsed time and any error fn returns. Nested calls
+// see the outer names in their context, so their lines are indented and
+// tagged with the full path (e.g. handle/loadUser).
+func Instrument(ctx context.Context, name string, fn func(context.Context) error) error {
+	depth := 0
+	if parent, ok := ctx.Value(spanKey).(span); ok {
+		name = parent.name + "/" + name
+		depth = parent.depth + 1
+	}
+	ctx = context.WithValue(ctx, spanKey, span{name: name, depth: depth})
+	
+	prefix := fmt.Sprintf("[%s] %s", RequestID(ctx), strings.Repeat("  ", depth))
+	instrumentLogger.Printf("%sstart %s", prefix, name)
+	
+	start := time.Now()
+	err := fn(ctx)
+	elapsed := time.Since(start)
+	
+	if err != nil {
+		instrumentLogger.Printf("%send %s (%v) error: %v", prefix, name, elapsed, err)
+	} else {
+		instrumentLogger.Printf("%send %s (%v)", prefix, name, elapsed)
+	}
+	return err
+}
+
 // Type definitions
 type Counter struct {
 	mu    sync.Mutex
 	value int
 }
 
-type SafeMap struct {
+// Pipeline chains same-typed transformations, each running as its own Stage
+type Pipeline[T any] struct {
+	source <-chan T
+	stages []pipelineStage[T]
+}
+
+type pipelineStage[T any] struct {
+	transform func(T) T
+	buffer    int
+}
+
+// contextKey is unexported so other packages can't collide with our keys
+type contextKey string
+
+const (
+	requestIDKey contextKey = "requestID"
+	spanKey      contextKey = "span"
+)
+
+// span is the name and nesting depth of the innermost Instrument call
+type span struct {
+	name  string
+	depth int
+}
+
+// RateLimiter is a token bucket: each operation takes a token from a
+// buffered channel, and a ticker puts tokens back at a fixed rate
+type RateLimiter struct {
+	tokens chan struct{}
+	ticker *time.Ticker
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Semaphore is a counting semaphore: each holder occupies one slot of a
+// buffered channel, so the channel's capacity caps concurrency
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// SafeCloser wraps a channel that many goroutines may send on and close.
+// Close is idempotent and Send reports false instead of panicking once
+// the channel is closed.
+type SafeCloser[T any] struct {
+	ch   chan T
+	done chan struct{}
+	once sync.Once
+	mu   sync.RWMutex  // Held for reading by senders, for writing by close(ch)
+}
+
+// SafeMapOf is a map guarded by a RWMutex, so any number of readers can
+// use it at once while writers get exclusive access. The zero value is
+// ready to use.
+type SafeMapOf[K comparable, V any] struct {
 	mu   sync.RWMutex
-	data map[string]int
+	data map[K]V
+}
+
+// SafeMap is the string-to-int map used in the demos. Unlike SafeMapOf,
+// its Keys are sorted, since string keys have a natural order.
+type SafeMap struct {
+	SafeMapOf[string, int]
 }
 
 // Method implementations
@@ -460,21 +1304,184 @@ func (c *Counter) Increment() {
 	c.value++
 }
 
+// Add adds delta, which may be negative, to the counter
+func (c *Counter) Add(delta int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value += delta
+}
+
+func (c *Counter) Decrement() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value--
+}
+
 func (c *Counter) Value() int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	return c.value
 }
 
-func (sm *SafeMap) Get(key string) (int, bool) {
+func (sm *SafeMapOf[K, V]) Get(key K) (V, bool) {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 	value, ok := sm.data[key]
 	return value, ok
 }
 
-func (sm *SafeMap) Set(key string, value int) {
+func (sm *SafeMapOf[K, V]) Set(key K, value V) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
+	if sm.data == nil {
+		sm.data = make(map[K]V)
+	}
 	sm.data[key] = value
 }
+
+func (sm *SafeMapOf[K, V]) Delete(key K) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	delete(sm.data, key)
+}
+
+func (sm *SafeMapOf[K, V]) Len() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return len(sm.data)
+}
+
+// Keys returns a snapshot of the keys in no particular order. The slice is
+// a copy, so it is unaffected by later changes to the map and safe to modify.
+func (sm *SafeMapOf[K, V]) Keys() []K {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	
+	keys := make([]K, 0, len(sm.data))
+	for key := range sm.data {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Keys returns a sorted snapshot of the keys, so output is deterministic
+func (sm *SafeMap) Keys() []string {
+	keys := sm.SafeMapOf.Keys()
+	slices.Sort(keys)
+	return keys
+}
+
+// Wait blocks until a token is available or ctx is done
+func (rl *RateLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-rl.tokens:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop halts the refill goroutine. Tokens already in the bucket can still be used.
+func (rl *RateLimiter) Stop() {
+	rl.once.Do(func() {
+		rl.ticker.Stop()
+		close(rl.done)
+	})
+}
+
+func (rl *RateLimiter) refill() {
+	for {
+		select {
+		case <-rl.ticker.C:
+			select {
+			case rl.tokens <- struct{}{}:
+			default:  // Bucket is full
+			}
+		case <-rl.done:
+			return
+		}
+	}
+}
+
+// Acquire blocks until a slot is free or ctx is done
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees a slot taken by Acquire. Releasing more often than
+// acquiring is a bug, so it panics rather than blocking forever.
+func (s *Semaphore) Release() {
+	select {
+	case <-s.slots:
+	default:
+		panic("semaphore: Release without matching Acquire")
+	}
+}
+
+// C returns the channel to receive from. It is closed by Close.
+func (s *SafeCloser[T]) C() <-chan T {
+	return s.ch
+}
+
+// Send delivers v, blocking until there is room or the SafeCloser is
+// closed. It returns false if v was not sent because of Close.
+func (s *SafeCloser[T]) Send(v T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	select {
+	case <-s.done:
+		return false
+	default:
+	}
+	
+	select {
+	case s.ch <- v:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+// Close closes the channel. It is safe to call more than once and from
+// several goroutines. Blocked senders are released first, so closing never
+// deadlocks waiting on a sender nobody is receiving from.
+func (s *SafeCloser[T]) Close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		close(s.ch)
+		s.mu.Unlock()
+	})
+}
+
+// Then appends an unbuffered transformation stage to the pipeline
+func (p *Pipeline[T]) Then(transform func(T) T) *Pipeline[T] {
+	return p.ThenBuffered(transform, 0)
+}
+
+// ThenBuffered appends a transformation stage whose output channel holds
+// up to buffer values. It panics if buffer is negative, like make does,
+// so that Run never has a bad stage to report.
+func (p *Pipeline[T]) ThenBuffered(transform func(T) T, buffer int) *Pipeline[T] {
+	if buffer < 0 {
+		panic(fmt.Sprintf("Pipeline.ThenBuffered: invalid buffer size %d: must not be negative", buffer))
+	}
+	p.stages = append(p.stages, pipelineStage[T]{transform: transform, buffer: buffer})
+	return p
+}
+
+// Run starts every stage and returns the final output channel.
+// Without any stages the source channel is returned unchanged.
+func (p *Pipeline[T]) Run() <-chan T {
+	output := p.source
+	for _, stage := range p.stages {
+		output = startStage(output, stage.transform, stage.buffer)
+	}
+	return output
+}
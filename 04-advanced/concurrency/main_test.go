@@ -0,0 +1,1085 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestComputeCompletes(t *testing.T) {
+	var steps []int
+	var reports []int
+	
+	err := Compute(context.Background(), 5, func(i int) error {
+		steps = append(steps, i)
+		return nil
+	}, func(done, total int) {
+		if total != 5 {
+			t.Errorf("progress total = %d; want 5", total)
+		}
+		reports = append(reports, done)
+	})
+	
+	if err != nil {
+		t.Fatalf("Compute() returned error: %v", err)
+	}
+	if len(steps) != 5 {
+		t.Errorf("ran %d steps; want 5", len(steps))
+	}
+	if len(reports) == 0 || reports[len(reports)-1] != 5 {
+		t.Errorf("progress reports = %v; want last report of 5", reports)
+	}
+}
+
+func TestComputeCancelledMidway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	
+	lastDone := 0
+	err := Compute(ctx, 10, func(i int) error {
+		if i == 3 {
+			cancel()
+		}
+		return nil
+	}, func(done, total int) {
+		lastDone = done
+	})
+	
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Compute() error = %v; want context.Canceled", err)
+	}
+	if lastDone != 4 {
+		t.Errorf("last progress = %d; want 4", lastDone)
+	}
+}
+
+func TestComputeReportsBeforeFirstStep(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	failFirst := func(i int) error { return errors.New("step failed") }
+	noop := func(i int) error { return nil }
+	
+	tests := []struct {
+		name string
+		ctx  context.Context
+		step func(i int) error
+	}{
+		{"already cancelled", cancelled, noop},
+		{"first step fails", context.Background(), failFirst},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reports []int
+			err := Compute(tt.ctx, 3, tt.step, func(done, total int) {
+				reports = append(reports, done)
+			})
+			if err == nil {
+				t.Fatal("Compute() returned nil error")
+			}
+			if !reflect.DeepEqual(reports, []int{0}) {
+				t.Errorf("progress reports = %v; want [0]", reports)
+			}
+		})
+	}
+	
+	// A zero total does no work and reports nothing
+	Compute(context.Background(), 0, noop, func(done, total int) {
+		t.Errorf("progress(%d, %d) called for zero total", done, total)
+	})
+}
+
+func TestComputeStepError(t *testing.T) {
+	stepErr := errors.New("step failed")
+	calls := 0
+	
+	err := Compute(context.Background(), 10, func(i int) error {
+		calls++
+		if i == 2 {
+			return stepErr
+		}
+		return nil
+	}, nil)
+	
+	if !errors.Is(err, stepErr) {
+		t.Fatalf("Compute() error = %v; want %v", err, stepErr)
+	}
+	if calls != 3 {
+		t.Errorf("step called %d times; want 3", calls)
+	}
+}
+
+func collect[T any](ch <-chan T) []T {
+	var result []T
+	for v := range ch {
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestPipelineThen(t *testing.T) {
+	output := Start(generate(1, 2, 3, 4)).
+		Then(func(n int) int { return n * n }).
+		Then(func(n int) int { return n + 1 }).
+		Run()
+	
+	result := collect(output)
+	expected := []int{2, 5, 10, 17}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("pipeline output = %v; want %v", result, expected)
+	}
+}
+
+func TestPipelineWithoutStages(t *testing.T) {
+	source := generate(1, 2, 3)
+	output := Start(source).Run()
+	if output != source {
+		t.Error("Run() without stages should return the source channel")
+	}
+	
+	// Ranging over the output terminates, proving the close propagated
+	result := collect(output)
+	if !reflect.DeepEqual(result, []int{1, 2, 3}) {
+		t.Errorf("pipeline output = %v; want [1 2 3]", result)
+	}
+}
+
+func TestBufferedPipelineOutput(t *testing.T) {
+	for _, buffer := range []int{0, 1, 2, 10} {
+		t.Run(fmt.Sprintf("buffer_%d", buffer), func(t *testing.T) {
+			output := Start(generate(1, 2, 3, 4, 5)).
+				ThenBuffered(func(n int) int { return n * 10 }, buffer).
+				ThenBuffered(func(n int) int { return n + 1 }, buffer).
+				Run()
+			
+			result := collect(output)
+			expected := []int{11, 21, 31, 41, 51}
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("pipeline output = %v; want %v", result, expected)
+			}
+		})
+	}
+}
+
+func TestNegativeBufferRejected(t *testing.T) {
+	if _, err := BufferedStage(generate(), func(n int) int { return n }, -1); err == nil {
+		t.Error("BufferedStage() with negative buffer should return error")
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("ThenBuffered() with negative buffer did not panic")
+		}
+	}()
+	Start(generate()).
+		Then(func(n int) int { return n }).
+		ThenBuffered(func(n int) int { return n }, -3)
+}
+
+func BenchmarkBufferedPipeline(b *testing.B) {
+	for _, buffer := range []int{0, 1, 10} {
+		b.Run(fmt.Sprintf("buffer_%d", buffer), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				source := make(chan int)
+				go func() {
+					defer close(source)
+					for n := 0; n < 1000; n++ {
+						source <- n
+					}
+				}()
+				
+				output := Start(source).
+					ThenBuffered(func(n int) int { return n * n }, buffer).
+					ThenBuffered(func(n int) int { return n + 1 }, buffer).
+					Run()
+				for range output {
+				}
+			}
+		})
+	}
+}
+
+func TestFetchURLs(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		
+		if r.URL.Path == "/fail" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, r.URL.Path)
+	}))
+	defer server.Close()
+	
+	okURLs := []string{server.URL + "/a", server.URL + "/b", server.URL + "/c"}
+	failURL := server.URL + "/fail"
+	
+	bodies, errs := FetchURLs(context.Background(), append(okURLs, failURL), 2)
+	
+	for _, url := range okURLs {
+		expected := url[len(server.URL):]
+		if got := string(bodies[url]); got != expected {
+			t.Errorf("bodies[%s] = %q; want %q", url, got, expected)
+		}
+	}
+	if len(bodies) != len(okURLs) {
+		t.Errorf("len(bodies) = %d; want %d", len(bodies), len(okURLs))
+	}
+	
+	if len(errs) != 1 || errs[failURL] == nil {
+		t.Errorf("errs = %v; want a single error for %s", errs, failURL)
+	}
+	
+	if maxInFlight > 2 {
+		t.Errorf("max concurrent requests = %d; want at most 2", maxInFlight)
+	}
+}
+
+func TestFetchURLsCancelled(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	
+	urls := []string{server.URL + "/a", server.URL + "/b"}
+	bodies, errs := FetchURLs(ctx, urls, 1)
+	
+	if len(bodies) != 0 {
+		t.Errorf("bodies = %v; want none", bodies)
+	}
+	for _, url := range urls {
+		if !errors.Is(errs[url], context.Canceled) {
+			t.Errorf("errs[%s] = %v; want context.Canceled", url, errs[url])
+		}
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("server received %d requests; want 0", n)
+	}
+}
+
+func TestDispatchRunsEveryTaskOnce(t *testing.T) {
+	for _, dispatch := range []struct {
+		name string
+		run  func([]func(), int)
+	}{
+		{"shared queue", Dispatch},
+		{"static split", DispatchStatic},
+	} {
+		for _, workers := range []int{0, 1, 3, 8, 50} {
+			t.Run(fmt.Sprintf("%s/%d workers", dispatch.name, workers), func(t *testing.T) {
+				counts := make([]int32, 37)
+				tasks := make([]func(), len(counts))
+				for i := range tasks {
+					i := i
+					tasks[i] = func() { atomic.AddInt32(&counts[i], 1) }
+				}
+				
+				dispatch.run(tasks, workers)
+				
+				for i, count := range counts {
+					if count != 1 {
+						t.Errorf("task %d ran %d times; want 1", i, count)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestDispatchSingleWorkerIsSerial(t *testing.T) {
+	var order []int
+	tasks := make([]func(), 5)
+	for i := range tasks {
+		i := i
+		tasks[i] = func() { order = append(order, i) }
+	}
+	
+	Dispatch(tasks, 1)
+	
+	if expected := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("execution order = %v; want %v", order, expected)
+	}
+}
+
+func TestDispatchNoTasks(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		Dispatch(nil, 4)
+		DispatchStatic(nil, 4)
+		close(done)
+	}()
+	
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatching no tasks did not return")
+	}
+}
+
+// skewedTasks front-loads the slow tasks, the worst case for a static split
+func skewedTasks() []func() {
+	durations := []time.Duration{2000, 2000, 200, 200, 200, 200, 200, 200}
+	tasks := make([]func(), len(durations))
+	for i, d := range durations {
+		d := d * time.Microsecond
+		tasks[i] = func() { time.Sleep(d) }
+	}
+	return tasks
+}
+
+func BenchmarkDispatchSkewed(b *testing.B) {
+	tasks := skewedTasks()
+	
+	b.Run("shared_queue", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Dispatch(tasks, 2)
+		}
+	})
+	
+	b.Run("static_split", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DispatchStatic(tasks, 2)
+		}
+	})
+}
+
+func captureInstrumentLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	instrumentLogger.SetOutput(&buf)
+	t.Cleanup(func() { instrumentLogger.SetOutput(os.Stdout) })
+	return &buf
+}
+
+func TestInstrumentLogsNestedCalls(t *testing.T) {
+	buf := captureInstrumentLog(t)
+	ctx := WithRequestID(context.Background(), "req-1")
+	
+	err := Instrument(ctx, "outer", func(ctx context.Context) error {
+		return Instrument(ctx, "inner", func(ctx context.Context) error {
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("Instrument() = %v; want nil", err)
+	}
+	
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	patterns := []string{
+		`^\s*\[req-1\] start outer$`,
+		`^\s*\[req-1\]   start outer/inner$`,
+		`^\s*\[req-1\]   end outer/inner \(\S+\)$`,
+		`^\s*\[req-1\] end outer \(\S+\)$`,
+	}
+	if len(lines) != len(patterns) {
+		t.Fatalf("logged %d lines; want %d:\n%s", len(lines), len(patterns), buf)
+	}
+	for i, pattern := range patterns {
+		if !regexp.MustCompile(pattern).MatchString(lines[i]) {
+			t.Errorf("line %d = %q; want match for %s", i, lines[i], pattern)
+		}
+	}
+}
+
+func TestInstrumentLogsError(t *testing.T) {
+	buf := captureInstrumentLog(t)
+	failure := errors.New("boom")
+	
+	err := Instrument(context.Background(), "op", func(ctx context.Context) error {
+		time.Sleep(time.Millisecond)
+		return failure
+	})
+	if err != failure {
+		t.Errorf("Instrument() = %v; want %v", err, failure)
+	}
+	
+	endLine := regexp.MustCompile(`\[-\] end op \((\S+)\) error: boom`).FindStringSubmatch(buf.String())
+	if endLine == nil {
+		t.Fatalf("log missing end line with error:\n%s", buf)
+	}
+	if elapsed, err := time.ParseDuration(endLine[1]); err != nil || elapsed < time.Millisecond {
+		t.Errorf("logged elapsed = %q; want a duration of at least 1ms", endLine[1])
+	}
+}
+
+func TestSafeCloserSendAfterClose(t *testing.T) {
+	closer := NewSafeCloser[int](1)
+	
+	if !closer.Send(1) {
+		t.Error("Send before Close = false; want true")
+	}
+	closer.Close()
+	closer.Close()
+	
+	if closer.Send(2) {
+		t.Error("Send after Close = true; want false")
+	}
+	
+	if got := collect(closer.C()); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("received %v; want [1]", got)
+	}
+}
+
+func TestSafeCloserReleasesBlockedSender(t *testing.T) {
+	closer := NewSafeCloser[int](0)
+	result := make(chan bool)
+	go func() {
+		result <- closer.Send(1)  // Nobody is receiving
+	}()
+	
+	time.Sleep(10 * time.Millisecond)
+	closer.Close()
+	
+	select {
+	case sent := <-result:
+		if sent {
+			t.Error("blocked Send = true; want false")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close did not release the blocked sender")
+	}
+}
+
+func TestSafeCloserConcurrentSendAndClose(t *testing.T) {
+	closer := NewSafeCloser[int](0)
+	
+	var received int32
+	drained := make(chan struct{})
+	go func() {
+		for range closer.C() {
+			atomic.AddInt32(&received, 1)
+		}
+		close(drained)
+	}()
+	
+	var sent int32
+	var wg sync.WaitGroup
+	for g := 0; g < 50; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if closer.Send(i) {
+					atomic.AddInt32(&sent, 1)
+				}
+				if g%10 == 0 && i == 50 {
+					closer.Close()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	closer.Close()
+	<-drained
+	
+	if sent != received {
+		t.Errorf("Send reported %d successful sends but %d values were received", sent, received)
+	}
+}
+
+func TestCounterConcurrentArithmetic(t *testing.T) {
+	counter := &Counter{}
+	var wg sync.WaitGroup
+	
+	for i := 0; i < 100; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			counter.Add(5)
+		}()
+		go func() {
+			defer wg.Done()
+			counter.Increment()
+		}()
+		go func() {
+			defer wg.Done()
+			counter.Decrement()
+		}()
+	}
+	wg.Wait()
+	
+	// 100 × (+5 +1 -1)
+	if got := counter.Value(); got != 500 {
+		t.Errorf("Value() = %d; want 500", got)
+	}
+	
+	counter.Add(-600)
+	if got := counter.Value(); got != -100 {
+		t.Errorf("Value() after Add(-600) = %d; want -100", got)
+	}
+}
+
+type Person struct {
+	Name string
+	Age  int
+}
+
+func TestSafeMapOperations(t *testing.T) {
+	var sm SafeMapOf[int, Person]  // The zero value is usable
+	
+	if _, ok := sm.Get(1); ok {
+		t.Error("Get on empty map reported ok = true")
+	}
+	
+	sm.Set(1, Person{Name: "Alice", Age: 30})
+	sm.Set(2, Person{Name: "Bob", Age: 25})
+	sm.Set(1, Person{Name: "Alice", Age: 31})
+	
+	if got, ok := sm.Get(1); !ok || got != (Person{Name: "Alice", Age: 31}) {
+		t.Errorf("Get(1) = %v, %t; want {Alice 31}, true", got, ok)
+	}
+	if got := sm.Len(); got != 2 {
+		t.Errorf("Len() = %d; want 2", got)
+	}
+	
+	sm.Delete(2)
+	sm.Delete(99)  // Deleting a missing key is a no-op
+	if got := sm.Keys(); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("Keys() = %v; want [1]", got)
+	}
+}
+
+func TestSafeMapConcurrentStringInt(t *testing.T) {
+	safeMap := &SafeMap{}
+	var wg sync.WaitGroup
+	
+	for w := 0; w < 10; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				key := fmt.Sprintf("w%d-%d", w, i)
+				safeMap.Set(key, i)
+				if i%2 == 0 {
+					safeMap.Delete(key)
+				}
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				safeMap.Get("w0-1")
+				safeMap.Len()
+				safeMap.Keys()
+			}
+		}()
+	}
+	wg.Wait()
+	
+	if got := safeMap.Len(); got != 500 {
+		t.Errorf("Len() = %d; want 500", got)
+	}
+}
+
+func TestSafeMapConcurrentIntPerson(t *testing.T) {
+	var people SafeMapOf[int, Person]
+	var wg sync.WaitGroup
+	
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(id int) {
+			defer wg.Done()
+			people.Set(id, Person{Name: fmt.Sprintf("person-%d", id), Age: id})
+		}(i)
+		go func(id int) {
+			defer wg.Done()
+			if p, ok := people.Get(id); ok && p.Age != id {
+				t.Errorf("Get(%d) = %v; want Age %d", id, p, id)
+			}
+		}(i)
+	}
+	wg.Wait()
+	
+	if got := people.Len(); got != 50 {
+		t.Errorf("Len() = %d; want 50", got)
+	}
+}
+
+func TestSafeMapStructKeys(t *testing.T) {
+	type point struct{ X, Y int }
+	var grid SafeMapOf[point, string]  // Any comparable key works
+	grid.Set(point{1, 2}, "a")
+	grid.Set(point{3, 4}, "b")
+	
+	if v, ok := grid.Get(point{1, 2}); !ok || v != "a" {
+		t.Errorf("Get({1 2}) = %q, %t; want \"a\", true", v, ok)
+	}
+	keys := grid.Keys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].X < keys[j].X })
+	if !reflect.DeepEqual(keys, []point{{1, 2}, {3, 4}}) {
+		t.Errorf("Keys() = %v; want [{1 2} {3 4}] in any order", keys)
+	}
+}
+
+func TestSafeMapKeysSortedSnapshot(t *testing.T) {
+	safeMap := &SafeMap{}
+	for _, key := range []string{"cherry", "apple", "banana", "date"} {
+		safeMap.Set(key, len(key))
+	}
+	safeMap.Delete("date")
+	
+	if got := safeMap.Len(); got != 3 {
+		t.Errorf("Len() after delete = %d; want 3", got)
+	}
+	
+	keys := safeMap.Keys()
+	expected := []string{"apple", "banana", "cherry"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("Keys() = %v; want %v", keys, expected)
+	}
+	
+	// The snapshot is independent of the map in both directions
+	keys[0] = "mutated"
+	safeMap.Set("elderberry", 10)
+	safeMap.Delete("banana")
+	
+	if !reflect.DeepEqual(keys, []string{"mutated", "banana", "cherry"}) {
+		t.Errorf("snapshot changed after map updates: %v", keys)
+	}
+	if got := safeMap.Keys(); !reflect.DeepEqual(got, []string{"apple", "cherry", "elderberry"}) {
+		t.Errorf("Keys() after updates = %v; want [apple cherry elderberry]", got)
+	}
+	if _, ok := safeMap.Get("mutated"); ok {
+		t.Error("modifying the Keys() result changed the map")
+	}
+}
+
+func TestRunWorkerPool(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobs    []int
+		workers int
+	}{
+		{"more jobs than workers", []int{1, 2, 3, 4, 5, 6, 7, 8}, 3},
+		{"more workers than jobs", []int{1, 2, 3}, 10},
+		{"no jobs", nil, 2},
+		{"zero workers", []int{4, 5}, 0},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := RunWorkerPool(tt.jobs, tt.workers, func(n int) (int, error) {
+				return n * n, nil
+			})
+			if err != nil {
+				t.Fatalf("RunWorkerPool() error = %v", err)
+			}
+			
+			expected := make([]int, 0, len(tt.jobs))
+			for _, n := range tt.jobs {
+				expected = append(expected, n*n)
+			}
+			sort.Ints(results)
+			if !reflect.DeepEqual(results, expected) {
+				t.Errorf("RunWorkerPool() = %v; want %v in any order", results, expected)
+			}
+		})
+	}
+}
+
+func TestRunWorkerPoolStopsOnError(t *testing.T) {
+	failure := errors.New("bad job")
+	jobs := make([]int, 1000)
+	for i := range jobs {
+		jobs[i] = i
+	}
+	
+	var processed int32
+	results, err := RunWorkerPool(jobs, 2, func(n int) (int, error) {
+		atomic.AddInt32(&processed, 1)
+		if n == 5 {
+			return 0, failure
+		}
+		return n, nil
+	})
+	
+	if !errors.Is(err, failure) {
+		t.Errorf("RunWorkerPool() error = %v; want %v", err, failure)
+	}
+	if results != nil {
+		t.Errorf("RunWorkerPool() results = %v; want nil on error", results)
+	}
+	if n := atomic.LoadInt32(&processed); n >= int32(len(jobs)) {
+		t.Errorf("processed %d jobs; want remaining work cancelled after the error", n)
+	}
+}
+
+func TestRunWorkerPoolRunsNothingAfterError(t *testing.T) {
+	failure := errors.New("slow bad job")
+	jobs := make([]int, 100000)
+	for i := range jobs {
+		jobs[i] = i
+	}
+	
+	// Fast jobs keep the other workers waiting on the job channel, which is
+	// when the producer could still hand out work after the failure
+	var failed atomic.Bool
+	var late atomic.Int32
+	_, err := RunWorkerPool(jobs, 4, func(n int) (int, error) {
+		if n == 0 {
+			time.Sleep(time.Millisecond)
+			failed.Store(true)
+			return 0, failure
+		}
+		if failed.Load() {
+			late.Add(1)
+		}
+		return n, nil
+	})
+	
+	if !errors.Is(err, failure) {
+		t.Fatalf("RunWorkerPool() error = %v; want %v", err, failure)
+	}
+	if n := late.Load(); n != 0 {
+		t.Errorf("%d jobs ran after the failing job; want 0", n)
+	}
+}
+
+func TestRunWorkerPoolOrdered(t *testing.T) {
+	jobs := []int{5, 1, 4, 2, 3, 0}
+	
+	// Earlier jobs sleep longer, so they finish last
+	results := RunWorkerPoolOrdered(jobs, 4, func(n int) int {
+		time.Sleep(time.Duration(n) * 2 * time.Millisecond)
+		return n * n
+	})
+	
+	expected := []int{25, 1, 16, 4, 9, 0}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("RunWorkerPoolOrdered(%v) = %v; want %v", jobs, results, expected)
+	}
+	
+	if got := RunWorkerPoolOrdered(nil, 2, func(n int) int { return n }); len(got) != 0 {
+		t.Errorf("RunWorkerPoolOrdered(nil) = %v; want empty", got)
+	}
+}
+
+func TestMergeCtxDrainsGoroutinesOnCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	// Inputs that are never closed, so only cancellation can stop mergeCtx
+	inputs := []chan int{make(chan int), make(chan int), make(chan int)}
+	for i, ch := range inputs {
+		go func(i int, ch chan int) {
+			for {
+				select {
+				case ch <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(i, ch)
+	}
+	
+	merged := mergeCtx(ctx, inputs[0], inputs[1], inputs[2])
+	for i := 0; i < 10; i++ {
+		<-merged
+	}
+	cancel()  // Stop consuming mid-stream without draining merged
+	
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines: %d before, %d after cancel; want all forwarding goroutines to exit", before, after)
+	}
+	
+	// At most a value already in flight remains before the channel closes
+	for range merged {
+	}
+}
+
+func TestMergeCtxForwardsEverything(t *testing.T) {
+	merged := mergeCtx(context.Background(), generate(1, 2, 3), generate(4, 5))
+	results := collect(merged)
+	sort.Ints(results)
+	
+	if expected := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(results, expected) {
+		t.Errorf("mergeCtx() = %v; want %v", results, expected)
+	}
+}
+
+func TestStageThreeStagePipeline(t *testing.T) {
+	squared := Stage(generate(1, 2, 3, 4), func(n int) int { return n * n })
+	incremented := Stage(squared, func(n int) int { return n + 1 })
+	labels := Stage(incremented, func(n int) string { return fmt.Sprintf("#%d", n) })
+	
+	expected := []string{"#2", "#5", "#10", "#17"}
+	if got := collect(labels); !reflect.DeepEqual(got, expected) {
+		t.Errorf("pipeline output = %v; want %v", got, expected)
+	}
+}
+
+func TestRateLimiterEnforcesRate(t *testing.T) {
+	// 10 tokens per 100ms: the first 10 Waits use the initial burst,
+	// the remaining 5 need a refill every 10ms
+	limiter := NewRateLimiter(10, 100*time.Millisecond)
+	defer limiter.Stop()
+	
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	elapsed := time.Since(start)
+	
+	if minimum := 50 * time.Millisecond; elapsed < minimum {
+		t.Errorf("15 Waits took %v; want at least %v", elapsed, minimum)
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Hour)
+	defer limiter.Stop()
+	
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait() error = %v", err)
+	}
+	
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() on empty bucket = %v; want context.DeadlineExceeded", err)
+	}
+}
+
+func TestRateLimiterDegenerateInterval(t *testing.T) {
+	// per/rate rounds to zero or below; these used to panic in NewTicker
+	for _, per := range []time.Duration{0, -time.Second, 3} {
+		limiter := NewRateLimiter(5, per)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		for i := 0; i < 10; i++ {
+			if err := limiter.Wait(ctx); err != nil {
+				t.Errorf("NewRateLimiter(5, %v): Wait() #%d error = %v", per, i, err)
+				break
+			}
+		}
+		cancel()
+		limiter.Stop()
+	}
+}
+
+func TestSemaphoreLimitsConcurrency(t *testing.T) {
+	const limit = 3
+	sem := NewSemaphore(limit)
+	
+	var mu sync.Mutex
+	active, peak := 0, 0
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sem.Acquire(context.Background()); err != nil {
+				t.Errorf("Acquire() error = %v", err)
+				return
+			}
+			defer sem.Release()
+			
+			mu.Lock()
+			active++
+			if active > peak {
+				peak = active
+			}
+			mu.Unlock()
+			
+			time.Sleep(5 * time.Millisecond)
+			
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	
+	if peak > limit {
+		t.Errorf("peak concurrent holders = %d; want at most %d", peak, limit)
+	}
+	if peak == 0 {
+		t.Error("no goroutine ever held the semaphore")
+	}
+}
+
+func TestSemaphoreAcquireCancelled(t *testing.T) {
+	sem := NewSemaphore(1)
+	if err := sem.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sem.Acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Acquire() on full semaphore = %v; want context.Canceled", err)
+	}
+	
+	sem.Release()
+	defer func() {
+		if recover() == nil {
+			t.Error("Release without Acquire did not panic")
+		}
+	}()
+	sem.Release()
+}
+
+func TestSemaphoreClampsSize(t *testing.T) {
+	for _, n := range []int{0, -3} {
+		sem := NewSemaphore(n)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		if err := sem.Acquire(ctx); err != nil {
+			t.Errorf("NewSemaphore(%d).Acquire() = %v; want nil", n, err)
+		} else {
+			sem.Release()
+		}
+		cancel()
+	}
+}
+
+func TestNonBlockingReceive(t *testing.T) {
+	empty := make(chan int)
+	
+	buffered := make(chan int, 1)
+	buffered <- 42
+	
+	closed := make(chan int)
+	close(closed)
+	
+	tests := []struct {
+		name          string
+		ch            chan int
+		expectedValue int
+		expectedOK    bool
+	}{
+		{"empty channel", empty, 0, false},
+		{"buffered value", buffered, 42, true},
+		{"buffered drained", buffered, 0, false},
+		{"closed channel", closed, 0, false},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := nonBlockingReceive(tt.ch)
+			if value != tt.expectedValue || ok != tt.expectedOK {
+				t.Errorf("nonBlockingReceive() = %d, %t; want %d, %t", value, ok, tt.expectedValue, tt.expectedOK)
+			}
+		})
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	t.Run("fast op completes", func(t *testing.T) {
+		opErr := errors.New("op failed")
+		if err := WithTimeout(time.Second, func() error { return nil }); err != nil {
+			t.Errorf("WithTimeout(fast) = %v; want nil", err)
+		}
+		if err := WithTimeout(time.Second, func() error { return opErr }); err != opErr {
+			t.Errorf("WithTimeout(failing) = %v; want %v", err, opErr)
+		}
+	})
+	
+	t.Run("slow op times out", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)  // Let the abandoned goroutine finish
+		
+		start := time.Now()
+		err := WithTimeout(20*time.Millisecond, func() error {
+			<-release
+			return nil
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("WithTimeout(slow) = %v; want context.DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("WithTimeout(slow) returned after %v; want about 20ms", elapsed)
+		}
+	})
+}
+
+func TestWithTimeoutContextCancelsOp(t *testing.T) {
+	stopped := make(chan struct{})
+	err := WithTimeoutContext(context.Background(), 20*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		close(stopped)
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WithTimeoutContext(slow) = %v; want context.DeadlineExceeded", err)
+	}
+	
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("op never saw its context cancelled")
+	}
+	
+	// Cancelling the parent is reported as-is, not as a timeout
+	release := make(chan struct{})
+	defer close(release)
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = WithTimeoutContext(parent, time.Second, func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WithTimeoutContext(cancelled parent) = %v; want context.Canceled", err)
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	var calls atomic.Int32
+	debounced := Debounce(50*time.Millisecond, func() {
+		calls.Add(1)
+	})
+	
+	for i := 0; i < 5; i++ {
+		debounced()
+		time.Sleep(5 * time.Millisecond)  // Well inside the quiet period
+	}
+	if got := calls.Load(); got != 0 {
+		t.Errorf("f ran %d times during the burst; want 0", got)
+	}
+	
+	time.Sleep(150 * time.Millisecond)
+	if got := calls.Load(); got != 1 {
+		t.Errorf("f ran %d times after the quiet period; want 1", got)
+	}
+	
+	// A later burst runs f again
+	debounced()
+	time.Sleep(150 * time.Millisecond)
+	if got := calls.Load(); got != 2 {
+		t.Errorf("f ran %d times after a second burst; want 2", got)
+	}
+}
@@ -1,12 +1,22 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -81,7 +91,12 @@ func demonstrateCustomErrorTypes() {
 		
 		// Type assertion
 		if validationErr, ok := err.(ValidationError); ok {
-			fmt.Printf("     Field: %s, Message: %s\n", validationErr.Field, validationErr.Message)
+			fmt.Printf("     Field: %s, Code: %s, Message: %s\n", validationErr.Field, validationErr.Code, validationErr.Message)
+		}
+		
+		// MarshalJSON gives API layers a machine-readable shape
+		if body, jsonErr := json.Marshal(err); jsonErr == nil {
+			fmt.Printf("     JSON: %s\n", body)
 		}
 	}
 	
@@ -128,6 +143,25 @@ func demonstrateErrorWrapping() {
 	if err != nil {
 		inspectError(err)
 	}
+	
+	// Error routing by type
+	fmt.Println("\n   Error routing:")
+	router := NewErrorRouter(func(err error) {
+		fmt.Printf("     Default handler: %v\n", err)
+	})
+	RegisterHandler(router, func(e ValidationError) {
+		fmt.Printf("     Validation handler: field %s\n", e.Field)
+	})
+	RegisterHandler(router, func(e DatabaseError) {
+		fmt.Printf("     Database handler: %s on %s\n", e.Operation, e.Table)
+	})
+	RegisterHandler(router, func(e AppError) {
+		fmt.Printf("     App handler: code %d\n", e.Code)
+	})
+	
+	router.Route(fmt.Errorf("saving profile: %w", ValidationError{Field: "email", Message: "invalid"}))
+	router.Route(saveUser(User{}))
+	router.Route(errors.New("something unexpected"))
 }
 
 // demonstrateErrorCheckingPatterns shows error checking patterns
@@ -148,6 +182,57 @@ func demonstrateErrorCheckingPatterns() {
 		fmt.Printf("   Multiple errors: %v\n", err)
 	}
 	
+	// Validating constructor reports every bad field at once
+	if _, err := NewValidatedUser("", -1, ""); err != nil {
+		fmt.Println("   NewValidatedUser problems:")
+		var multi MultiError
+		if errors.As(err, &multi) {
+			for _, problem := range multi.Unwrap() {
+				fmt.Printf("     - %v\n", problem)
+			}
+		}
+	}
+	
+	// errors.As looks inside a MultiError through Unwrap() []error
+	multi := MultiError{Errors: []error{
+		os.ErrNotExist,
+		ValidationError{Field: "email", Message: "invalid format"},
+	}}
+	var fieldErr ValidationError
+	if errors.As(multi, &fieldErr) {
+		fmt.Printf("   Found validation error in MultiError: field %q\n", fieldErr.Field)
+	}
+	fmt.Printf("   MultiError contains os.ErrNotExist: %t\n", errors.Is(multi, os.ErrNotExist))
+	
+	// The same aggregation with the standard library's errors.Join
+	joined := JoinValidationErrors(
+		validateUser(User{Name: "", Age: 30}),
+		nil,  // Passing checks contribute nothing
+		os.ErrPermission,
+	)
+	fmt.Printf("   Joined errors: %q\n", joined)
+	if errors.As(joined, &fieldErr) {
+		fmt.Printf("   Found validation error in joined error: field %q\n", fieldErr.Field)
+	}
+	fmt.Printf("   Joined error contains os.ErrPermission: %t\n", errors.Is(joined, os.ErrPermission))
+	
+	// Batch validation with indexed errors
+	users := []User{
+		{Name: "Alice", Age: 30},
+		{Name: "", Age: 25},
+		{Name: "Bob", Age: 40},
+		{Name: "Carol", Age: -1},
+	}
+	invalid := ValidateUsers(users)
+	indexes := make([]int, 0, len(invalid))
+	for i := range invalid {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)  // Map order is random; print by user index
+	for _, i := range indexes {
+		fmt.Printf("   User %d invalid: %v\n", i, invalid[i])
+	}
+	
 	// Error recovery
 	result, err := safeOperation()
 	if err != nil {
@@ -161,6 +246,33 @@ func demonstrateErrorCheckingPatterns() {
 func demonstratePanicRecover() {
 	fmt.Println("\n5. Panic and Recover:")
 	
+	// Classifying recovered values
+	fmt.Println("   Classifying panics:")
+	panickers := []func(){
+		func() {
+			var values []int
+			_ = values[3]
+		},
+		func() { panic(errors.New("custom failure")) },
+		func() { panic("something went wrong") },
+		func() { panic(42) },
+		func() {},
+	}
+	for _, f := range panickers {
+		kind, err := callAndClassify(f)
+		fmt.Printf("     %-7s %v\n", kind+":", err)
+	}
+	
+	// Guarding a function that returns a value
+	fmt.Println("   Guarded call:")
+	scores := []int{90, 85}
+	if _, err := Guard(func() int { return scores[5] }); err != nil {
+		fmt.Printf("     Error: %v\n", err)
+	}
+	if first, err := Guard(func() int { return scores[0] }); err == nil {
+		fmt.Printf("     First score: %d\n", first)
+	}
+	
 	// Panic for programming errors
 	fmt.Println("   Panic for programming errors:")
 	defer func() {
@@ -193,9 +305,23 @@ func demonstrateCommonPatterns() {
 	
 	// Error middleware pattern
 	fmt.Println("   Error middleware pattern:")
-	handler := errorMiddleware(httpHandler)
+	handler := errorMiddleware(http.HandlerFunc(httpHandler))
 	handler.ServeHTTP(nil, nil)
 	
+	// Logging middleware with real requests and a recorder
+	fmt.Println("   Logging middleware:")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/crash", httpHandler)
+	logged := LoggingMiddleware(mux)
+	for _, path := range []string{"/users", "/crash"} {
+		rec := httptest.NewRecorder()
+		logged.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		fmt.Printf("     Client saw status %d\n", rec.Code)
+	}
+	
 	// Error logging
 	fmt.Println("   Error logging:")
 	logError(errors.New("test error"), map[string]interface{}{
@@ -203,6 +329,13 @@ func demonstrateCommonPatterns() {
 		"user_id":    "456",
 	})
 	
+	// Structured logging
+	fmt.Print("     ")
+	logErrorJSON(os.Stdout, ValidationError{Field: "email", Message: "invalid format"}, map[string]interface{}{
+		"request_id": "123",
+		"user_id":    "456",
+	})
+	
 	// Error retry
 	fmt.Println("   Error retry:")
 	if err := retryOperation(func() error {
@@ -211,12 +344,126 @@ func demonstrateCommonPatterns() {
 		fmt.Printf("     Retry failed: %v\n", err)
 	}
 	
+	// Exponential backoff
+	fmt.Println("   Retry with backoff:")
+	backoffAttempts := 0
+	lastAttempt := time.Now()
+	err := retryWithBackoff(func() error {
+		backoffAttempts++
+		fmt.Printf("     Attempt %d after %v\n", backoffAttempts, time.Since(lastAttempt).Round(5*time.Millisecond))
+		lastAttempt = time.Now()
+		if backoffAttempts < 4 {
+			return errors.New("temporary error")
+		}
+		return nil
+	}, 5, 10*time.Millisecond)
+	fmt.Printf("     Result: %v\n", err)
+	
+	// Only transient database errors are worth retrying
+	fmt.Println("   Retryable database errors:")
+	for _, dbErr := range []DatabaseError{
+		{Operation: "insert", Table: "orders", Err: errors.New("connection failed"), Retryable: true},
+		{Operation: "insert", Table: "orders", Err: errors.New("constraint violation")},
+	} {
+		attempts := 0
+		err := retryWithBackoff(func() error {
+			attempts++
+			return dbErr
+		}, 3, time.Millisecond)
+		fmt.Printf("     %v: retryable=%t, attempts=%d\n", dbErr.Err, IsRetryable(err), attempts)
+	}
+	
+	// Retry until the result itself is acceptable
+	fmt.Println("   Retry with validation:")
+	replicas := 0
+	synced, err := RetryValidated(func() (int, error) {
+		replicas++  // Simulate an eventually-consistent read catching up
+		fmt.Printf("     Read: %d/3 replicas in sync\n", replicas)
+		return replicas, nil
+	}, func(n int) bool {
+		return n == 3
+	}, 5)
+	fmt.Printf("     Result: %d replicas, err=%v\n", synced, err)
+	
+	// Retries that stop when the caller gives up
+	fmt.Println("   Retry with context:")
+	retryCtx, retryCancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer retryCancel()
+	err = retryWithContext(retryCtx, func() error {
+		return errors.New("temporary error")
+	}, 100)
+	fmt.Printf("     Gave up: %v\n", err)
+	
+	// Retry several independent operations
+	fmt.Println("   Retry all:")
+	flakyAttempts := 0
+	results := RetryAll([]func() error{
+		func() error { return nil },
+		func() error { return errors.New("permanent error") },
+		func() error {
+			flakyAttempts++
+			if flakyAttempts < 2 {
+				return errors.New("flaky error")
+			}
+			return nil
+		},
+	}, 3)
+	for i, err := range results {
+		if err != nil {
+			fmt.Printf("     Operation %d failed: %v\n", i, err)
+		} else {
+			fmt.Printf("     Operation %d succeeded\n", i)
+		}
+	}
+	
+	// HTTP retry on 429/503
+	fmt.Println("   HTTP retry transport:")
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+	
+	client := &http.Client{Transport: &RetryTransport{MaxRetries: 3}}
+	if resp, err := client.Get(server.URL); err != nil {
+		fmt.Printf("     Request failed: %v\n", err)
+	} else {
+		resp.Body.Close()
+		fmt.Printf("     Status %d after %d requests\n", resp.StatusCode, requests.Load())
+	}
+	
 	// Error metrics
 	fmt.Println("   Error metrics:")
 	metrics := &ErrorMetrics{ErrorCounts: make(map[string]int)}
 	metrics.RecordError(errors.New("test error"))
 	metrics.RecordError(errors.New("test error"))
 	fmt.Printf("     Error count: %d\n", metrics.GetErrorCount("*errors.errorString"))
+	
+	metrics.RecordError(ValidationError{Field: "email"})
+	metrics.RecordError(ValidationError{Field: "name"})
+	metrics.RecordError(ValidationError{Field: "age"})
+	metrics.RecordError(DatabaseError{Table: "users"})
+	fmt.Println("     Top errors:")
+	for _, entry := range metrics.TopErrors(2) {
+		fmt.Printf("       %s: %d\n", entry.Type, entry.Count)
+	}
+	
+	// Snapshot and clear, e.g. once per reporting interval
+	fmt.Printf("     Total before reset: %d\n", metrics.Total())
+	metrics.Reset()
+	fmt.Printf("     Total after reset: %d\n", metrics.Total())
+	
+	// Error rate over the last minute
+	rateMetrics := NewErrorMetrics(nil)
+	for i := 0; i < 12; i++ {
+		rateMetrics.RecordError(errors.New("timeout"))
+	}
+	fmt.Printf("     Errors per minute: %.0f\n", rateMetrics.RatePerMinute())
 }
 
 // Helper functions
@@ -247,6 +494,7 @@ func validateUser(user User) error {
 	if user.Name == "" {
 		return ValidationError{
 			Field:   "name",
+			Code:    "required",
 			Message: "name is required",
 		}
 	}
@@ -254,6 +502,7 @@ func validateUser(user User) error {
 	if user.Age < 0 {
 		return ValidationError{
 			Field:   "age",
+			Code:    "positive",
 			Message: "age must be positive",
 		}
 	}
@@ -267,6 +516,7 @@ func saveUser(user User) error {
 		Operation: "save",
 		Table:     "users",
 		Err:       errors.New("connection failed"),
+		Retryable: true,
 	}
 }
 
@@ -310,6 +560,29 @@ func inspectError(err error) {
 	fmt.Printf("     Unknown error: %v\n", err)
 }
 
+// NewErrorMetrics creates empty metrics that read the time from now.
+// A nil clock defaults to time.Now.
+func NewErrorMetrics(now func() time.Time) *ErrorMetrics {
+	return &ErrorMetrics{ErrorCounts: make(map[string]int), now: now}
+}
+
+// NewErrorRouter creates a router that sends unmatched errors to defaultHandler
+func NewErrorRouter(defaultHandler func(error)) *ErrorRouter {
+	return &ErrorRouter{
+		handlers:       make(map[reflect.Type]func(error)),
+		defaultHandler: defaultHandler,
+	}
+}
+
+// RegisterHandler registers handler for errors of the concrete type E.
+// Methods can't have type parameters, so this is a function instead.
+func RegisterHandler[E error](router *ErrorRouter, handler func(E)) {
+	errType := reflect.TypeOf((*E)(nil)).Elem()
+	router.handlers[errType] = func(err error) {
+		handler(err.(E))
+	}
+}
+
 func processData(data []byte) ([]byte, error) {
 	if len(data) == 0 {
 		return nil, errors.New("empty data")
@@ -327,25 +600,77 @@ func isValid(data []byte) bool {
 }
 
 func validateUserMultiple(user User) error {
-	var errors MultiError
+	var errs MultiError
 	
 	if user.Name == "" {
-		errors.Errors = append(errors.Errors, errors.New("name is required"))
+		errs.Add(errors.New("name is required"))
 	}
 	
 	if user.Age < 0 {
-		errors.Errors = append(errors.Errors, errors.New("age must be positive"))
+		errs.Add(errors.New("age must be positive"))
 	}
 	
 	if user.Email == "" {
-		errors.Errors = append(errors.Errors, errors.New("email is required"))
+		errs.Add(errors.New("email is required"))
 	}
 	
-	if len(errors.Errors) > 0 {
-		return errors
+	return errs.ErrorOrNil()
+}
+
+// NewValidatedUser builds a User after checking every field. Instead of
+// stopping at the first problem, it returns all of them as ValidationErrors
+// inside a MultiError, along with a zero User.
+func NewValidatedUser(name string, age int, email string) (User, error) {
+	var errs MultiError
+	
+	if name == "" {
+		errs.Add(ValidationError{Field: "name", Code: "required", Message: "name is required"})
+	}
+	if age < 0 {
+		errs.Add(ValidationError{Field: "age", Code: "positive", Message: "age must be positive"})
+	}
+	if email == "" {
+		errs.Add(ValidationError{Field: "email", Code: "required", Message: "email is required"})
 	}
 	
-	return nil
+	if err := errs.ErrorOrNil(); err != nil {
+		return User{}, err
+	}
+	return User{Name: name, Age: age, Email: email}, nil
+}
+
+// JoinValidationErrors is the errors.Join alternative to MultiError. It drops
+// nil errors, returns nil if none are left, returns a lone error unchanged,
+// and otherwise joins them. errors.Is and errors.As see every joined error.
+func JoinValidationErrors(errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	default:
+		return errors.Join(nonNil...)
+	}
+}
+
+// ValidateUsers validates every user with validateUser and returns the
+// errors keyed by the index of the offending user. Valid users are omitted,
+// so an all-valid slice yields an empty map.
+func ValidateUsers(users []User) map[int]error {
+	errs := make(map[int]error)
+	for i, user := range users {
+		if err := validateUser(user); err != nil {
+			errs[i] = err
+		}
+	}
+	return errs
 }
 
 func safeOperation() (result interface{}, err error) {
@@ -360,6 +685,52 @@ func safeOperation() (result interface{}, err error) {
 	return result, nil
 }
 
+// ClassifyPanic normalizes a value returned by recover into an error and
+// reports which kind of value was panicked with: "runtime" for runtime
+// errors such as a nil dereference or index out of range, "error" for any
+// other error, "string" for string messages and "other" for everything
+// else. A nil value means nothing panicked and yields ("none", nil).
+func ClassifyPanic(r interface{}) (kind string, err error) {
+	switch v := r.(type) {
+	case nil:
+		return "none", nil
+	case runtime.Error:
+		return "runtime", v
+	case error:
+		return "error", v
+	case string:
+		return "string", errors.New(v)
+	default:
+		return "other", fmt.Errorf("panic: %v", v)
+	}
+}
+
+// callAndClassify runs f and classifies whatever it panics with
+func callAndClassify(f func()) (kind string, err error) {
+	defer func() {
+		kind, err = ClassifyPanic(recover())
+	}()
+	
+	f()
+	return "none", nil
+}
+
+// Guard calls f and returns its result. If f panics, Guard recovers and
+// returns the zero value of T together with the panic as an error; the
+// error wraps the cause from ClassifyPanic, so errors.As can reach a
+// runtime.Error.
+func Guard[T any](f func() T) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			_, cause := ClassifyPanic(r)
+			result, err = zero, fmt.Errorf("recovered panic: %w", cause)
+		}
+	}()
+	
+	return f(), nil
+}
+
 func riskyOperation() interface{} {
 	// Simulate panic
 	panic("risky operation failed")
@@ -389,10 +760,58 @@ func httpHandler(w http.ResponseWriter, r *http.Request) {
 	panic("handler panic")
 }
 
+// middlewareLogger is where LoggingMiddleware writes, replaceable in tests
+var middlewareLogger = log.New(os.Stdout, "     ", 0)
+
+// LoggingMiddleware logs the method, path and final status of every request
+// handled by next. A panic in next is logged and recovered; the client gets
+// a 500 unless next had already started the response, in which case the
+// status can no longer change. http.ErrAbortHandler is re-panicked so that
+// net/http still aborts the connection as intended.
+func LoggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}  // net/http's default
+		defer func() {
+			if p := recover(); p != nil {
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+				middlewareLogger.Printf("%s %s panic: %v", r.Method, r.URL.Path, p)
+				if !rec.wroteHeader {
+					http.Error(rec, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}
+			middlewareLogger.Printf("%s %s -> %d", r.Method, r.URL.Path, rec.status)
+		}()
+		
+		next.ServeHTTP(rec, r)
+	})
+}
+
 func logError(err error, context map[string]interface{}) {
 	fmt.Printf("     Error: %v, Context: %+v\n", err, context)
 }
 
+// logErrorJSON writes err and its context fields to w as a single JSON
+// object per line, with the message under "error" and the Go type under
+// "type". These two keys take precedence over context fields of the same name.
+func logErrorJSON(w io.Writer, err error, fields map[string]interface{}) error {
+	entry := make(map[string]interface{}, len(fields)+2)
+	for key, value := range fields {
+		entry[key] = value
+	}
+	
+	if err != nil {
+		entry["error"] = err.Error()
+		entry["type"] = fmt.Sprintf("%T", err)
+	} else {
+		entry["error"] = nil
+		entry["type"] = nil
+	}
+	
+	return json.NewEncoder(w).Encode(entry)
+}
+
 func retryOperation(operation func() error, maxRetries int) error {
 	var err error
 	for i := 0; i < maxRetries; i++ {
@@ -408,6 +827,142 @@ func retryOperation(operation func() error, maxRetries int) error {
 	return fmt.Errorf("operation failed after %d retries: %w", maxRetries, err)
 }
 
+// retryWithContext is retryOperation that can be cancelled: it returns
+// ctx.Err() as soon as ctx is done, whether that happens before an attempt
+// or while waiting between attempts.
+func retryWithContext(ctx context.Context, operation func() error, maxRetries int) error {
+	var err error
+	for i := 0; i < maxRetries; i++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		
+		err = operation()
+		if err == nil {
+			return nil
+		}
+		
+		if i < maxRetries-1 {
+			timer := time.NewTimer(time.Duration(i+1) * time.Millisecond)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+	return fmt.Errorf("operation failed after %d retries: %w", maxRetries, err)
+}
+
+// sleep is time.Sleep, replaceable in tests to observe retry delays
+var sleep = time.Sleep
+
+// IsRetryable reports whether err wraps a DatabaseError marked Retryable
+func IsRetryable(err error) bool {
+	var dbErr DatabaseError
+	return errors.As(err, &dbErr) && dbErr.Retryable
+}
+
+// retryWithBackoff is retryOperation with exponential backoff: it makes up
+// to maxRetries attempts, waiting base, 2×base, 4×base, ... between them.
+// A DatabaseError that is not Retryable is returned at once; any other
+// error is retried.
+func retryWithBackoff(operation func() error, maxRetries int, base time.Duration) error {
+	var err error
+	delay := base
+	for i := 0; i < maxRetries; i++ {
+		err = operation()
+		if err == nil {
+			return nil
+		}
+		
+		var dbErr DatabaseError
+		if errors.As(err, &dbErr) && !dbErr.Retryable {
+			return fmt.Errorf("operation failed with non-retryable error: %w", err)
+		}
+		
+		if i < maxRetries-1 {
+			sleep(delay)
+			delay *= 2
+		}
+	}
+	return fmt.Errorf("operation failed after %d retries: %w", maxRetries, err)
+}
+
+// RetryValidated retries op until it returns a value that passes validate,
+// not only until it stops failing. It makes up to maxRetries attempts with
+// the same linear delay as retryOperation. When the attempts run out it
+// returns the last value along with an error explaining why it was rejected.
+// op is always called at least once, even if maxRetries is less than 1.
+func RetryValidated[T any](op func() (T, error), validate func(T) bool, maxRetries int) (T, error) {
+	if maxRetries < 1 {
+		maxRetries = 1
+	}
+	
+	var result T
+	var err error
+	for i := 0; i < maxRetries; i++ {
+		result, err = op()
+		if err == nil && validate(result) {
+			return result, nil
+		}
+		
+		if i < maxRetries-1 {
+			sleep(time.Duration(i+1) * time.Millisecond)
+		}
+	}
+	
+	if err != nil {
+		return result, fmt.Errorf("operation failed after %d retries: %w", maxRetries, err)
+	}
+	return result, fmt.Errorf("result %v still invalid after %d retries", result, maxRetries)
+}
+
+// RetryAll retries each operation independently using retryOperation.
+// The returned slice is index-aligned with ops: nil where the operation
+// eventually succeeded, the final error where it did not.
+func RetryAll(ops []func() error, maxRetries int) []error {
+	results := make([]error, len(ops))
+	for i, op := range ops {
+		results[i] = retryOperation(op, maxRetries)
+	}
+	return results
+}
+
+// isIdempotent reports whether a request with this method can be safely repeated
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the delay requested by a Retry-After header, which may
+// be a number of seconds or an HTTP date. ok is false if the header is absent
+// or can't be parsed.
+func retryAfter(resp *http.Response) (delay time.Duration, ok bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	
+	if when, err := http.ParseTime(header); err == nil {
+		delay = time.Until(when)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
 // Type definitions
 type User struct {
 	Name  string
@@ -417,6 +972,7 @@ type User struct {
 
 type ValidationError struct {
 	Field   string
+	Code    string  // Machine-readable reason, e.g. "required"
 	Message string
 }
 
@@ -424,6 +980,7 @@ type DatabaseError struct {
 	Operation string
 	Table     string
 	Err       error
+	Retryable bool  // Whether repeating the operation might succeed
 }
 
 type ErrorCode int
@@ -445,23 +1002,64 @@ type MultiError struct {
 	Errors []error
 }
 
+// ErrorRouter dispatches errors to handlers registered per concrete type
+type ErrorRouter struct {
+	handlers       map[reflect.Type]func(error)
+	defaultHandler func(error)
+}
+
+// RetryTransport is an http.RoundTripper that retries idempotent requests
+// answered with 429 Too Many Requests or 503 Service Unavailable.
+// It waits for the Retry-After delay when the server sends one, and for
+// Backoff otherwise.
+type RetryTransport struct {
+	Base       http.RoundTripper  // Defaults to http.DefaultTransport
+	MaxRetries int                // Retries after the first attempt
+	Backoff    time.Duration      // Delay used when there is no Retry-After header
+}
+
 type ErrorMetrics struct {
 	ErrorCounts map[string]int
 	mu          sync.RWMutex
+	
+	// Per-second counts for the last minute, indexed by Unix second mod 60
+	now     func() time.Time
+	seconds [60]secondBucket
 }
 
-type http.Handler interface {
-	ServeHTTP(http.ResponseWriter, *http.Request)
+// secondBucket counts the errors recorded during one wall-clock second
+type secondBucket struct {
+	unix  int64
+	count int
 }
 
-type http.ResponseWriter interface{}
-type http.Request struct{}
+// ErrorCount is the number of errors recorded for one error type
+type ErrorCount struct {
+	Type  string
+	Count int
+}
+
+// statusRecorder wraps an http.ResponseWriter to remember the status code
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
 
 // Method implementations
 func (e ValidationError) Error() string {
 	return fmt.Sprintf("validation error on field '%s': %s", e.Field, e.Message)
 }
 
+// MarshalJSON encodes the error as {"field":...,"code":...,"message":...}
+func (e ValidationError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Field   string `json:"field"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}{e.Field, e.Code, e.Message})
+}
+
 func (e DatabaseError) Error() string {
 	return fmt.Sprintf("database error during %s on table %s: %v", 
 		e.Operation, e.Table, e.Err)
@@ -490,12 +1088,68 @@ func (e MultiError) Error() string {
 	return strings.Join(messages, "; ")
 }
 
+// Unwrap exposes the collected errors to errors.Is and errors.As
+func (e MultiError) Unwrap() []error {
+	return e.Errors
+}
+
+// Add collects err; nil errors are ignored
+func (e *MultiError) Add(err error) {
+	if err != nil {
+		e.Errors = append(e.Errors, err)
+	}
+}
+
+// HasErrors reports whether any error has been collected
+func (e *MultiError) HasErrors() bool {
+	return len(e.Errors) > 0
+}
+
+// ErrorOrNil returns nil if nothing was collected and the MultiError
+// otherwise, so callers don't return a non-nil error holding no errors
+func (e *MultiError) ErrorOrNil() error {
+	if !e.HasErrors() {
+		return nil
+	}
+	return *e
+}
+
 func (em *ErrorMetrics) RecordError(err error) {
 	em.mu.Lock()
 	defer em.mu.Unlock()
 	
 	errorType := fmt.Sprintf("%T", err)
 	em.ErrorCounts[errorType]++
+	
+	second := em.clock().Unix()
+	bucket := &em.seconds[((second%60)+60)%60]  // % keeps the sign, so pre-1970 seconds are negative
+	if bucket.unix != second {
+		*bucket = secondBucket{unix: second}  // Reuse a slot left over from an older minute
+	}
+	bucket.count++
+}
+
+// RatePerMinute returns the number of errors recorded in the last 60
+// seconds, including the current one
+func (em *ErrorMetrics) RatePerMinute() float64 {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	
+	now := em.clock().Unix()
+	total := 0
+	for _, bucket := range em.seconds {
+		if bucket.unix > now-60 && bucket.unix <= now {
+			total += bucket.count
+		}
+	}
+	return float64(total)
+}
+
+func (em *ErrorMetrics) clock() time.Time {
+	if em.now == nil {
+		return time.Now()
+	}
+	return em.now()
 }
 
 func (em *ErrorMetrics) GetErrorCount(errorType string) int {
@@ -503,3 +1157,141 @@ func (em *ErrorMetrics) GetErrorCount(errorType string) int {
 	defer em.mu.RUnlock()
 	return em.ErrorCounts[errorType]
 }
+
+// Total returns the number of errors recorded across all types
+func (em *ErrorMetrics) Total() int {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	
+	total := 0
+	for _, count := range em.ErrorCounts {
+		total += count
+	}
+	return total
+}
+
+// Reset clears all recorded counts
+func (em *ErrorMetrics) Reset() {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.ErrorCounts = make(map[string]int)
+	em.seconds = [60]secondBucket{}
+}
+
+// TopErrors returns the n most frequent error types, highest count first,
+// with ties broken by type name. If fewer than n types have been recorded,
+// all of them are returned.
+func (em *ErrorMetrics) TopErrors(n int) []ErrorCount {
+	em.mu.RLock()
+	counts := make([]ErrorCount, 0, len(em.ErrorCounts))
+	for errorType, count := range em.ErrorCounts {
+		counts = append(counts, ErrorCount{Type: errorType, Count: count})
+	}
+	em.mu.RUnlock()
+	
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Type < counts[j].Type
+	})
+	
+	if n < 0 {
+		n = 0
+	}
+	if n < len(counts) {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// Route walks the unwrap chain of err, from the outermost error inward,
+// and calls the handler of the first error whose type is registered.
+// If nothing matches, the default handler is called. A nil error is ignored.
+func (r *ErrorRouter) Route(err error) {
+	if err == nil {
+		return
+	}
+	
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if handler, ok := r.handlers[reflect.TypeOf(e)]; ok {
+			handler(e)
+			return
+		}
+	}
+	
+	if r.defaultHandler != nil {
+		r.defaultHandler(err)
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	
+	// A body can only be replayed if the request knows how to recreate it
+	canRetry := isIdempotent(req.Method) && (req.Body == nil || req.GetBody != nil)
+	
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+		
+		resp, err := base.RoundTrip(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+		
+		retryable := resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode == http.StatusServiceUnavailable
+		if !canRetry || !retryable || attempt >= t.MaxRetries {
+			return resp, nil
+		}
+		
+		delay, ok := retryAfter(resp)
+		if !ok {
+			delay = t.Backoff
+		}
+		
+		// Drain and close the body so the connection can be reused
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// WriteHeader records the status before passing it on
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status, r.wroteHeader = status, true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write implies a 200 status if WriteHeader wasn't called, as net/http does
+func (r *statusRecorder) Write(data []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	return r.ResponseWriter.Write(data)
+}
+
+// Unwrap exposes the wrapped writer so http.ResponseController can still
+// reach optional interfaces such as http.Flusher and http.Hijacker
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,1027 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAll(t *testing.T) {
+	var attempts [3]int
+	ops := []func() error{
+		func() error {
+			attempts[0]++
+			return nil
+		},
+		func() error {
+			attempts[1]++
+			return errors.New("always fails")
+		},
+		func() error {
+			attempts[2]++
+			if attempts[2] < 3 {
+				return errors.New("not yet")
+			}
+			return nil
+		},
+	}
+	
+	results := RetryAll(ops, 3)
+	
+	if len(results) != len(ops) {
+		t.Fatalf("RetryAll() returned %d results; want %d", len(results), len(ops))
+	}
+	if results[0] != nil {
+		t.Errorf("results[0] = %v; want nil", results[0])
+	}
+	if results[1] == nil {
+		t.Error("results[1] = nil; want error")
+	}
+	if results[2] != nil {
+		t.Errorf("results[2] = %v; want nil", results[2])
+	}
+	
+	expectedAttempts := [3]int{1, 3, 3}
+	if attempts != expectedAttempts {
+		t.Errorf("attempts = %v; want %v", attempts, expectedAttempts)
+	}
+}
+
+func TestRetryAllEmpty(t *testing.T) {
+	results := RetryAll(nil, 3)
+	if len(results) != 0 {
+		t.Errorf("RetryAll(nil) = %v; want empty", results)
+	}
+}
+
+func TestErrorRouter(t *testing.T) {
+	var handled string
+	router := NewErrorRouter(func(err error) {
+		handled = "default"
+	})
+	RegisterHandler(router, func(e ValidationError) {
+		handled = "validation:" + e.Field
+	})
+	RegisterHandler(router, func(e DatabaseError) {
+		handled = "database:" + e.Table
+	})
+	RegisterHandler(router, func(e AppError) {
+		handled = "app:" + e.Message
+	})
+	
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"validation", ValidationError{Field: "name"}, "validation:name"},
+		{"wrapped validation", fmt.Errorf("outer: %w", ValidationError{Field: "age"}), "validation:age"},
+		{"double wrapped database", fmt.Errorf("a: %w", fmt.Errorf("b: %w", DatabaseError{Table: "users"})), "database:users"},
+		{"wrapped app", fmt.Errorf("ctx: %w", AppError{Message: "not found"}), "app:not found"},
+		{"unregistered", errors.New("plain"), "default"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = ""
+			router.Route(tt.err)
+			if handled != tt.expected {
+				t.Errorf("Route(%v) ran %q; want %q", tt.err, handled, tt.expected)
+			}
+		})
+	}
+}
+
+func TestErrorRouterNilError(t *testing.T) {
+	called := false
+	router := NewErrorRouter(func(err error) {
+		called = true
+	})
+	
+	router.Route(nil)
+	if called {
+		t.Error("Route(nil) should not call any handler")
+	}
+}
+
+func TestRetryTransportRetriesUntilSuccess(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "done")
+	}))
+	defer server.Close()
+	
+	client := &http.Client{Transport: &RetryTransport{MaxRetries: 3, Backoff: time.Millisecond}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d; want %d", resp.StatusCode, http.StatusOK)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("server saw %d requests; want 3", n)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != "done" {
+		t.Errorf("body = %q; want %q", body, "done")
+	}
+}
+
+func TestRetryTransportHonorsMaxRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	
+	client := &http.Client{Transport: &RetryTransport{MaxRetries: 2}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status = %d; want %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("server saw %d requests; want 3", n)
+	}
+}
+
+func TestRetryTransportSkipsNonIdempotent(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	
+	client := &http.Client{Transport: &RetryTransport{MaxRetries: 3, Backoff: time.Millisecond}}
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("Post() returned error: %v", err)
+	}
+	resp.Body.Close()
+	
+	if n := requests.Load(); n != 1 {
+		t.Errorf("server saw %d requests; want 1", n)
+	}
+}
+
+func TestRetryTransportReplaysBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	
+	req, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader("payload"))
+	client := &http.Client{Transport: &RetryTransport{MaxRetries: 3, Backoff: time.Millisecond}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() returned error: %v", err)
+	}
+	resp.Body.Close()
+	
+	if !reflect.DeepEqual(bodies, []string{"payload", "payload"}) {
+		t.Errorf("server received bodies %q; want the payload twice", bodies)
+	}
+}
+
+func TestValidateUsers(t *testing.T) {
+	users := []User{
+		{Name: "Alice", Age: 30},
+		{Name: "", Age: 25},
+		{Name: "Bob", Age: 40},
+		{Name: "Carol", Age: -1},
+	}
+	
+	errs := ValidateUsers(users)
+	if len(errs) != 2 {
+		t.Fatalf("ValidateUsers() returned %d errors; want 2: %v", len(errs), errs)
+	}
+	
+	expectedFields := map[int]string{1: "name", 3: "age"}
+	for i, field := range expectedFields {
+		var validationErr ValidationError
+		if !errors.As(errs[i], &validationErr) {
+			t.Errorf("errs[%d] = %v; want ValidationError", i, errs[i])
+			continue
+		}
+		if validationErr.Field != field {
+			t.Errorf("errs[%d].Field = %q; want %q", i, validationErr.Field, field)
+		}
+	}
+}
+
+func TestValidateUsersAllValid(t *testing.T) {
+	errs := ValidateUsers([]User{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 0}})
+	if errs == nil || len(errs) != 0 {
+		t.Errorf("ValidateUsers() = %v; want empty map", errs)
+	}
+}
+
+func TestClassifyPanic(t *testing.T) {
+	customErr := errors.New("custom failure")
+	
+	tests := []struct {
+		name         string
+		f            func()
+		expectedKind string
+		expectedErr  string
+	}{
+		{"index out of range", func() {
+			var values []int
+			_ = values[3]
+		}, "runtime", "index out of range"},
+		{"nil map write", func() {
+			var m map[string]int
+			m["a"] = 1
+		}, "runtime", "nil map"},
+		{"error value", func() { panic(customErr) }, "error", "custom failure"},
+		{"string value", func() { panic("something went wrong") }, "string", "something went wrong"},
+		{"other value", func() { panic(42) }, "other", "panic: 42"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, err := callAndClassify(tt.f)
+			if kind != tt.expectedKind {
+				t.Errorf("kind = %q; want %q", kind, tt.expectedKind)
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("err = %v; want it to contain %q", err, tt.expectedErr)
+			}
+		})
+	}
+	
+	if _, err := callAndClassify(func() { panic(customErr) }); !errors.Is(err, customErr) {
+		t.Errorf("err = %v; want the panicked error itself", err)
+	}
+}
+
+func TestClassifyPanicNil(t *testing.T) {
+	kind, err := ClassifyPanic(nil)
+	if kind != "none" || err != nil {
+		t.Errorf("ClassifyPanic(nil) = %q, %v; want \"none\", nil", kind, err)
+	}
+	
+	if kind, err := callAndClassify(func() {}); kind != "none" || err != nil {
+		t.Errorf("callAndClassify(no panic) = %q, %v; want \"none\", nil", kind, err)
+	}
+}
+
+func TestMultiErrorUnwrap(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	other := errors.New("other")
+	
+	tests := []struct {
+		name     string
+		multi    MultiError
+		expected bool
+	}{
+		{"sentinel first", MultiError{Errors: []error{sentinel, other}}, true},
+		{"sentinel last", MultiError{Errors: []error{other, sentinel}}, true},
+		{"sentinel wrapped", MultiError{Errors: []error{other, fmt.Errorf("context: %w", sentinel)}}, true},
+		{"sentinel absent", MultiError{Errors: []error{other}}, false},
+		{"empty", MultiError{}, false},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.multi, sentinel); got != tt.expected {
+				t.Errorf("errors.Is(%v, sentinel) = %t; want %t", tt.multi, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMultiErrorAs(t *testing.T) {
+	multi := MultiError{Errors: []error{
+		errors.New("name is required"),
+		ValidationError{Field: "email", Message: "invalid format"},
+	}}
+	
+	var validationErr ValidationError
+	if !errors.As(multi, &validationErr) {
+		t.Fatalf("errors.As(%v, &ValidationError) = false; want true", multi)
+	}
+	if validationErr.Field != "email" {
+		t.Errorf("validationErr.Field = %q; want %q", validationErr.Field, "email")
+	}
+}
+
+func TestMultiErrorAdd(t *testing.T) {
+	var errs MultiError
+	
+	errs.Add(nil)
+	if errs.HasErrors() {
+		t.Errorf("HasErrors() after Add(nil) = true; want false")
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		t.Errorf("ErrorOrNil() on empty collector = %v; want nil", err)
+	}
+	
+	first := errors.New("first")
+	second := errors.New("second")
+	errs.Add(first)
+	errs.Add(nil)
+	errs.Add(second)
+	
+	if !errs.HasErrors() {
+		t.Errorf("HasErrors() = false; want true")
+	}
+	if len(errs.Errors) != 2 {
+		t.Errorf("len(Errors) = %d; want 2", len(errs.Errors))
+	}
+	
+	err := errs.ErrorOrNil()
+	if err == nil || err.Error() != "first; second" {
+		t.Errorf("ErrorOrNil() = %v; want \"first; second\"", err)
+	}
+	if !errors.Is(err, second) {
+		t.Errorf("errors.Is(ErrorOrNil(), second) = false; want true")
+	}
+}
+
+func TestValidateUserMultiple(t *testing.T) {
+	if err := validateUserMultiple(User{Name: "Alice", Age: 30, Email: "alice@example.com"}); err != nil {
+		t.Errorf("validateUserMultiple(valid) = %v; want nil", err)
+	}
+	
+	err := validateUserMultiple(User{Name: "", Age: -5, Email: ""})
+	var multi MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 3 {
+		t.Errorf("validateUserMultiple(invalid) = %v; want MultiError with 3 errors", err)
+	}
+}
+
+// recordSleeps replaces sleep with a fake that records requested delays
+func recordSleeps(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var delays []time.Duration
+	sleep = func(d time.Duration) { delays = append(delays, d) }
+	t.Cleanup(func() { sleep = time.Sleep })
+	return &delays
+}
+
+func TestRetryWithBackoffDoublesDelay(t *testing.T) {
+	delays := recordSleeps(t)
+	attempts := 0
+	
+	err := retryWithBackoff(func() error {
+		attempts++
+		return errors.New("always fails")
+	}, 4, 5*time.Millisecond)
+	
+	if err == nil {
+		t.Fatal("retryWithBackoff() = nil; want error")
+	}
+	if attempts != 4 {
+		t.Errorf("attempts = %d; want 4", attempts)
+	}
+	
+	expected := []time.Duration{5 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond}
+	if !reflect.DeepEqual(*delays, expected) {
+		t.Errorf("delays = %v; want %v", *delays, expected)
+	}
+}
+
+func TestRetryWithBackoffStopsOnSuccess(t *testing.T) {
+	delays := recordSleeps(t)
+	attempts := 0
+	
+	err := retryWithBackoff(func() error {
+		attempts++
+		if attempts < 2 {
+			return errors.New("flaky")
+		}
+		return nil
+	}, 5, time.Millisecond)
+	
+	if err != nil {
+		t.Errorf("retryWithBackoff() = %v; want nil", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d; want 2", attempts)
+	}
+	if len(*delays) != 1 {
+		t.Errorf("slept %d times; want 1", len(*delays))
+	}
+}
+
+func TestRetryWithContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	attempts := 0
+	
+	start := time.Now()
+	err := retryWithContext(ctx, func() error {
+		attempts++
+		cancel()
+		return errors.New("temporary error")
+	}, 1000)
+	elapsed := time.Since(start)
+	
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("retryWithContext() = %v; want context.Canceled", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d; want 1", attempts)
+	}
+	if elapsed > 100*time.Millisecond {
+		t.Errorf("retryWithContext() took %v after cancellation; want it to return promptly", elapsed)
+	}
+}
+
+func TestRetryWithContextDeadlineDuringWait(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	
+	err := retryWithContext(ctx, func() error {
+		return errors.New("temporary error")
+	}, 1000)  // Linear delays would take far longer than the deadline
+	
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("retryWithContext() = %v; want context.DeadlineExceeded", err)
+	}
+}
+
+func TestRetryWithContextSucceeds(t *testing.T) {
+	attempts := 0
+	err := retryWithContext(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("flaky")
+		}
+		return nil
+	}, 5)
+	
+	if err != nil || attempts != 3 {
+		t.Errorf("retryWithContext() = %v after %d attempts; want nil after 3", err, attempts)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"retryable", DatabaseError{Err: errors.New("connection failed"), Retryable: true}, true},
+		{"not retryable", DatabaseError{Err: errors.New("constraint violation")}, false},
+		{"wrapped retryable", fmt.Errorf("save: %w", DatabaseError{Retryable: true}), true},
+		{"plain error", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.expected {
+				t.Errorf("IsRetryable(%v) = %t; want %t", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetryWithBackoffDatabaseErrors(t *testing.T) {
+	recordSleeps(t)
+	
+	tests := []struct {
+		name             string
+		err              DatabaseError
+		expectedAttempts int
+	}{
+		{"connection failed is retried", DatabaseError{Err: errors.New("connection failed"), Retryable: true}, 3},
+		{"constraint violation stops immediately", DatabaseError{Err: errors.New("constraint violation")}, 1},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := retryWithBackoff(func() error {
+				attempts++
+				return tt.err
+			}, 3, time.Millisecond)
+			
+			if attempts != tt.expectedAttempts {
+				t.Errorf("attempts = %d; want %d", attempts, tt.expectedAttempts)
+			}
+			var dbErr DatabaseError
+			if !errors.As(err, &dbErr) || dbErr.Err != tt.err.Err {
+				t.Errorf("retryWithBackoff() = %v; want it to wrap %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRetryValidated(t *testing.T) {
+	recordSleeps(t)
+	isPositive := func(n int) bool { return n > 0 }
+	
+	tests := []struct {
+		name             string
+		results          []int
+		errs             []error
+		expected         int
+		expectedAttempts int
+		wantErr          string
+	}{
+		{
+			name:             "immediately valid",
+			results:          []int{5},
+			expected:         5,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "eventually valid",
+			results:          []int{0, -1, 7},
+			expected:         7,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "error then valid",
+			results:          []int{0, 2},
+			errs:             []error{errors.New("timeout"), nil},
+			expected:         2,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "never valid",
+			results:          []int{0, -1, -2, -3},
+			expected:         -3,
+			expectedAttempts: 4,
+			wantErr:          "result -3 still invalid after 4 retries",
+		},
+		{
+			name:             "last attempt errors",
+			results:          []int{0, 0, 0, 0},
+			errs:             []error{nil, nil, nil, errors.New("timeout")},
+			expected:         0,
+			expectedAttempts: 4,
+			wantErr:          "operation failed after 4 retries: timeout",
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			result, err := RetryValidated(func() (int, error) {
+				i := attempts
+				attempts++
+				var err error
+				if i < len(tt.errs) {
+					err = tt.errs[i]
+				}
+				return tt.results[i], err
+			}, isPositive, 4)
+			
+			if result != tt.expected {
+				t.Errorf("RetryValidated() result = %d; want %d", result, tt.expected)
+			}
+			if attempts != tt.expectedAttempts {
+				t.Errorf("attempts = %d; want %d", attempts, tt.expectedAttempts)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("RetryValidated() error = %v; want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("RetryValidated() error = %v; want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryValidatedAttemptsAtLeastOnce(t *testing.T) {
+	recordSleeps(t)
+	isPositive := func(n int) bool { return n > 0 }
+	
+	for _, maxRetries := range []int{0, -2} {
+		attempts := 0
+		result, err := RetryValidated(func() (int, error) {
+			attempts++
+			return 3, nil
+		}, isPositive, maxRetries)
+		
+		if result != 3 || err != nil {
+			t.Errorf("RetryValidated(maxRetries=%d) = %d, %v; want 3, nil", maxRetries, result, err)
+		}
+		if attempts != 1 {
+			t.Errorf("RetryValidated(maxRetries=%d) made %d attempts; want 1", maxRetries, attempts)
+		}
+		
+		_, err = RetryValidated(func() (int, error) { return -1, nil }, isPositive, maxRetries)
+		if want := "result -1 still invalid after 1 retries"; err == nil || err.Error() != want {
+			t.Errorf("RetryValidated(maxRetries=%d) error = %v; want %q", maxRetries, err, want)
+		}
+	}
+}
+
+func TestLogErrorJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := ValidationError{Field: "email", Message: "invalid format"}
+	
+	if writeErr := logErrorJSON(&buf, err, map[string]interface{}{
+		"request_id": "123",
+		"attempt":    2,
+		"error":      "overridden",
+	}); writeErr != nil {
+		t.Fatalf("logErrorJSON() = %v; want nil", writeErr)
+	}
+	
+	var entry map[string]interface{}
+	if jsonErr := json.Unmarshal(buf.Bytes(), &entry); jsonErr != nil {
+		t.Fatalf("output %q is not valid JSON: %v", buf.String(), jsonErr)
+	}
+	
+	expected := map[string]interface{}{
+		"error":      "validation error on field 'email': invalid format",
+		"type":       "main.ValidationError",
+		"request_id": "123",
+		"attempt":    float64(2),
+	}
+	if !reflect.DeepEqual(entry, expected) {
+		t.Errorf("logged entry = %v; want %v", entry, expected)
+	}
+	
+	if !strings.HasSuffix(buf.String(), "\n") || strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("output %q should be a single line", buf.String())
+	}
+}
+
+func TestErrorMetricsTopErrors(t *testing.T) {
+	metrics := &ErrorMetrics{ErrorCounts: make(map[string]int)}
+	for i := 0; i < 3; i++ {
+		metrics.RecordError(ValidationError{})
+	}
+	for i := 0; i < 2; i++ {
+		metrics.RecordError(DatabaseError{})
+		metrics.RecordError(AppError{})
+	}
+	metrics.RecordError(errors.New("plain"))
+	
+	all := []ErrorCount{
+		{Type: "main.ValidationError", Count: 3},
+		{Type: "main.AppError", Count: 2},
+		{Type: "main.DatabaseError", Count: 2},
+		{Type: "*errors.errorString", Count: 1},
+	}
+	
+	tests := []struct {
+		n        int
+		expected []ErrorCount
+	}{
+		{2, all[:2]},
+		{3, all[:3]},
+		{4, all},
+		{10, all},
+		{0, []ErrorCount{}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("top %d", tt.n), func(t *testing.T) {
+			if got := metrics.TopErrors(tt.n); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("TopErrors(%d) = %v; want %v", tt.n, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestErrorMetricsTotalAndReset(t *testing.T) {
+	metrics := &ErrorMetrics{ErrorCounts: make(map[string]int)}
+	metrics.RecordError(errors.New("a"))
+	metrics.RecordError(errors.New("b"))
+	metrics.RecordError(ValidationError{})
+	
+	if got := metrics.Total(); got != 3 {
+		t.Errorf("Total() = %d; want 3", got)
+	}
+	
+	metrics.Reset()
+	if got := metrics.Total(); got != 0 {
+		t.Errorf("Total() after Reset = %d; want 0", got)
+	}
+	if got := metrics.GetErrorCount("main.ValidationError"); got != 0 {
+		t.Errorf("GetErrorCount() after Reset = %d; want 0", got)
+	}
+}
+
+func TestErrorMetricsConcurrentRecordAndReset(t *testing.T) {
+	metrics := &ErrorMetrics{ErrorCounts: make(map[string]int)}
+	var wg sync.WaitGroup
+	
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				metrics.RecordError(errors.New("boom"))
+				metrics.Total()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				metrics.Reset()
+				metrics.TopErrors(1)
+			}
+		}()
+	}
+	wg.Wait()
+	
+	if total := metrics.Total(); total < 0 || total > 1000 {
+		t.Errorf("Total() = %d; want between 0 and 1000", total)
+	}
+}
+
+func TestErrorMetricsRatePerMinute(t *testing.T) {
+	current := time.Unix(1700000000, 0)
+	metrics := NewErrorMetrics(func() time.Time { return current })
+	record := func(n int) {
+		for i := 0; i < n; i++ {
+			metrics.RecordError(errors.New("boom"))
+		}
+	}
+	
+	record(5)  // t=0s
+	current = current.Add(30 * time.Second)
+	record(10)  // t=30s
+	current = current.Add(29 * time.Second)
+	record(3)  // t=59s
+	
+	if got := metrics.RatePerMinute(); got != 18 {
+		t.Errorf("RatePerMinute() at t=59s = %v; want 18", got)
+	}
+	
+	// The t=0s bucket falls out of the window
+	current = current.Add(time.Second)
+	if got := metrics.RatePerMinute(); got != 13 {
+		t.Errorf("RatePerMinute() at t=60s = %v; want 13", got)
+	}
+	
+	// Recording at t=90s reuses the t=30s slot
+	current = current.Add(30 * time.Second)
+	record(1)
+	if got := metrics.RatePerMinute(); got != 4 {
+		t.Errorf("RatePerMinute() at t=90s = %v; want 4", got)
+	}
+	
+	// Long after the last error the rate drops to zero
+	current = current.Add(10 * time.Minute)
+	if got := metrics.RatePerMinute(); got != 0 {
+		t.Errorf("RatePerMinute() after 10 idle minutes = %v; want 0", got)
+	}
+}
+
+func TestErrorMetricsPreEpochClock(t *testing.T) {
+	current := time.Unix(-61, 0)  // Negative Unix seconds
+	metrics := NewErrorMetrics(func() time.Time { return current })
+	
+	metrics.RecordError(errors.New("boom"))  // t=-61s
+	current = current.Add(30 * time.Second)
+	metrics.RecordError(errors.New("boom"))  // t=-31s
+	current = current.Add(40 * time.Second)
+	metrics.RecordError(errors.New("boom"))  // t=9s, across the epoch
+	
+	if got := metrics.RatePerMinute(); got != 2 {
+		t.Errorf("RatePerMinute() at t=9s = %v; want 2", got)
+	}
+	if got := metrics.Total(); got != 3 {
+		t.Errorf("Total() = %d; want 3", got)
+	}
+}
+
+func TestNewValidatedUser(t *testing.T) {
+	user, err := NewValidatedUser("Alice", 30, "alice@example.com")
+	if err != nil {
+		t.Fatalf("NewValidatedUser(valid) error = %v; want nil", err)
+	}
+	if expected := (User{Name: "Alice", Age: 30, Email: "alice@example.com"}); user != expected {
+		t.Errorf("NewValidatedUser(valid) = %+v; want %+v", user, expected)
+	}
+	
+	user, err = NewValidatedUser("", -1, "")
+	var multi MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("NewValidatedUser(invalid) error = %v; want MultiError", err)
+	}
+	wrapped := multi.Unwrap()
+	if len(wrapped) != 3 {
+		t.Fatalf("NewValidatedUser(invalid) wrapped %d errors; want 3: %v", len(wrapped), err)
+	}
+	var fields []string
+	for _, e := range wrapped {
+		var fieldErr ValidationError
+		if !errors.As(e, &fieldErr) {
+			t.Errorf("wrapped error %v is not a ValidationError", e)
+			continue
+		}
+		fields = append(fields, fieldErr.Field)
+	}
+	if expected := []string{"name", "age", "email"}; !reflect.DeepEqual(fields, expected) {
+		t.Errorf("invalid fields = %v; want %v", fields, expected)
+	}
+	if user != (User{}) {
+		t.Errorf("NewValidatedUser(invalid) user = %+v; want zero User", user)
+	}
+}
+
+func TestValidationErrorJSON(t *testing.T) {
+	err := validateUser(User{Name: "Alice", Age: -1})
+	
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("json.Marshal(%v) error: %v", err, marshalErr)
+	}
+	expected := `{"field":"age","code":"positive","message":"age must be positive"}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal(%v) = %s; want %s", err, data, expected)
+	}
+	
+	// Wrapping doesn't hide the struct or its new field
+	var validationErr ValidationError
+	if !errors.As(fmt.Errorf("creating user: %w", err), &validationErr) {
+		t.Fatalf("errors.As(wrapped, &ValidationError) = false; want true")
+	}
+	if validationErr.Code != "positive" {
+		t.Errorf("Code = %q; want \"positive\"", validationErr.Code)
+	}
+}
+
+func TestGuard(t *testing.T) {
+	t.Run("normal return", func(t *testing.T) {
+		result, err := Guard(func() string { return "ok" })
+		if err != nil || result != "ok" {
+			t.Errorf("Guard(normal) = %q, %v; want \"ok\", nil", result, err)
+		}
+	})
+	
+	t.Run("index out of range", func(t *testing.T) {
+		values := []int{1, 2, 3}
+		index := 5
+		result, err := Guard(func() int { return values[index] })
+		if err == nil {
+			t.Fatal("Guard(out of range) returned nil error")
+		}
+		if result != 0 {
+			t.Errorf("Guard(out of range) result = %d; want zero value", result)
+		}
+		var runtimeErr runtime.Error
+		if !errors.As(err, &runtimeErr) {
+			t.Errorf("errors.As(%v, &runtime.Error) = false; want true", err)
+		}
+	})
+	
+	t.Run("panic with value", func(t *testing.T) {
+		result, err := Guard(func() *User { panic("boom") })
+		if result != nil || err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Errorf("Guard(panic) = %v, %v; want nil, error mentioning boom", result, err)
+		}
+	})
+}
+
+func TestJoinValidationErrors(t *testing.T) {
+	t.Run("all nil", func(t *testing.T) {
+		if err := JoinValidationErrors(nil, nil); err != nil {
+			t.Errorf("JoinValidationErrors(nil, nil) = %v; want nil", err)
+		}
+		if err := JoinValidationErrors(); err != nil {
+			t.Errorf("JoinValidationErrors() = %v; want nil", err)
+		}
+	})
+	
+	t.Run("single error passes through", func(t *testing.T) {
+		single := ValidationError{Field: "name", Code: "required", Message: "name is required"}
+		err := JoinValidationErrors(nil, single, nil)
+		if err != error(single) {
+			t.Errorf("JoinValidationErrors(nil, single, nil) = %#v; want the error itself", err)
+		}
+	})
+	
+	t.Run("multiple errors unwrap", func(t *testing.T) {
+		sentinel := errors.New("sentinel")
+		err := JoinValidationErrors(
+			ValidationError{Field: "age", Code: "positive"},
+			nil,
+			fmt.Errorf("wrapped: %w", sentinel),
+		)
+		
+		joined, ok := err.(interface{ Unwrap() []error })
+		if !ok || len(joined.Unwrap()) != 2 {
+			t.Fatalf("JoinValidationErrors(...) = %v; want 2 joined errors", err)
+		}
+		if !errors.Is(err, sentinel) {
+			t.Errorf("errors.Is(joined, sentinel) = false; want true")
+		}
+		var validationErr ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "age" {
+			t.Errorf("errors.As(joined, &ValidationError) = %+v; want field age", validationErr)
+		}
+	})
+}
+
+// captureMiddlewareLog redirects LoggingMiddleware output into a buffer
+func captureMiddlewareLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	original := middlewareLogger
+	middlewareLogger = log.New(&buf, "", 0)
+	t.Cleanup(func() { middlewareLogger = original })
+	return &buf
+}
+
+func TestLoggingMiddlewareRecoversPanic(t *testing.T) {
+	logs := captureMiddlewareLog(t)
+	handler := LoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+	
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/crash", nil))
+	
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusInternalServerError)
+	}
+	for _, want := range []string{"GET /crash panic: boom", "GET /crash -> 500"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("log %q does not contain %q", logs.String(), want)
+		}
+	}
+}
+
+func TestLoggingMiddlewarePassesThrough(t *testing.T) {
+	logs := captureMiddlewareLog(t)
+	handler := LoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/greet", nil))
+	
+	if rec.Code != http.StatusOK || rec.Body.String() != "hello" {
+		t.Errorf("response = %d %q; want 200 \"hello\"", rec.Code, rec.Body.String())
+	}
+	if want := "POST /greet -> 200"; !strings.Contains(logs.String(), want) {
+		t.Errorf("log %q does not contain %q", logs.String(), want)
+	}
+}
+
+func TestLoggingMiddlewareKeepsStartedResponse(t *testing.T) {
+	captureMiddlewareLog(t)
+	handler := LoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic("late failure")
+	}))
+	
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/late", nil))
+	
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("status = %d; want %d (already sent before the panic)", rec.Code, http.StatusAccepted)
+	}
+}
+
+func TestLoggingMiddlewareKeepsFlusher(t *testing.T) {
+	captureMiddlewareLog(t)
+	var flushErr error
+	handler := LoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		flushErr = http.NewResponseController(w).Flush()
+	}))
+	
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	
+	if flushErr != nil {
+		t.Errorf("ResponseController.Flush() through middleware = %v; want nil", flushErr)
+	}
+	if !rec.Flushed {
+		t.Error("recorder was not flushed")
+	}
+}
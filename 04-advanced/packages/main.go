@@ -1,9 +1,11 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
-	"yourproject/pkg/math"
-	"yourproject/pkg/user"
+	
+	"go-learning/pkg/math"
+	"go-learning/pkg/user"
 )
 
 // This example demonstrates Go's package system
@@ -32,12 +34,48 @@ func demonstratePackageUsage() {
 	result = math.Subtract(5, 3)
 	fmt.Printf("   math.Subtract(5, 3) = %d\n", result)
 	
+	result = math.Multiply(4, 3)
+	fmt.Printf("   math.Multiply(4, 3) = %d\n", result)
+	
+	if result, err := math.Divide(7, 2); err == nil {
+		fmt.Printf("   math.Divide(7, 2) = %d\n", result)
+	}
+	if _, err := math.Divide(1, 0); err != nil {
+		fmt.Printf("   math.Divide(1, 0) error: %v\n", err)
+	}
+	
+	// Generic helpers work with any ordered type
+	fmt.Printf("   math.Max(2.5, 1.5) = %.1f\n", math.Max(2.5, 1.5))
+	fmt.Printf("   math.Min(4, 9) = %d\n", math.Min(4, 9))
+	fmt.Printf("   math.Clamp(150, 0, 100) = %d\n", math.Clamp(150, 0, 100))
+	fmt.Printf("   math.Clamp(-0.5, 0.0, 1.0) = %.1f\n", math.Clamp(-0.5, 0.0, 1.0))
+	
+	fmt.Printf("   math.Pow(2, 10) = %.0f\n", math.Pow(2, 10))
+	if root, err := math.Sqrt(16); err == nil {
+		fmt.Printf("   math.Sqrt(16) = %.0f\n", root)
+	}
+	
 	// Use user package
 	u := user.New("Alice", 30)
 	fmt.Printf("   user: %+v\n", u)
 	
 	name := u.GetName()
 	fmt.Printf("   user name: %s\n", name)
+	
+	// Round-trip through JSON
+	data, err := json.Marshal(u)
+	if err != nil {
+		fmt.Printf("   encode error: %v\n", err)
+		return
+	}
+	fmt.Printf("   user JSON: %s\n", data)
+	
+	decoded, err := user.FromJSON(data)
+	if err != nil {
+		fmt.Printf("   decode error: %v\n", err)
+		return
+	}
+	fmt.Printf("   decoded user: %+v\n", decoded)
 }
 
 func demonstratePackageVisibility() {
@@ -1,15 +1,26 @@
 package main
 
 import (
+	"bytes"
 	"errors"
+	"flag"
 	"fmt"
+	"math"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 )
 
 // This example demonstrates Go's testing framework
 // Run this with: go test -v
 
+// update regenerates golden files: go test -run TestProcessUserGolden -update
+var update = flag.Bool("update", false, "update golden files in testdata/")
+
 func TestAdd(t *testing.T) {
 	result := Add(2, 3)
 	if result != 5 {
@@ -81,6 +92,60 @@ func TestAddTableDriven(t *testing.T) {
 	}
 }
 
+func TestSubtractTableDriven(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        int
+		b        int
+		expected int
+	}{
+		{"positive numbers", 5, 3, 2},
+		{"negative result", 3, 5, -2},
+		{"negative numbers", -2, -3, 1},
+		{"subtract zero", 7, 0, 7},
+		{"from zero", 0, 7, -7},
+		{"large values", math.MaxInt / 2, -(math.MaxInt / 2), math.MaxInt - 1},
+		{"wraps on overflow", math.MinInt, 1, math.MaxInt},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Subtract(tt.a, tt.b)
+			if result != tt.expected {
+				t.Errorf("Subtract(%d, %d) = %d; want %d", 
+					tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMultiplyTableDriven(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        int
+		b        int
+		expected int
+	}{
+		{"positive numbers", 2, 3, 6},
+		{"negative numbers", -2, -3, 6},
+		{"mixed signs", -2, 3, -6},
+		{"zero", 0, 5, 0},
+		{"identity", 1, 42, 42},
+		{"large values", math.MaxInt / 2, 2, math.MaxInt - 1},
+		{"wraps on overflow", math.MaxInt, 2, -2},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Multiply(tt.a, tt.b)
+			if result != tt.expected {
+				t.Errorf("Multiply(%d, %d) = %d; want %d", 
+					tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestDivideTableDriven(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -118,6 +183,31 @@ func TestDivideTableDriven(t *testing.T) {
 	}
 }
 
+// FuzzDivide checks properties that must hold for any input rather than
+// specific results. go test runs only the seed corpus; to generate new
+// inputs run: go test -run=^$ -fuzz=FuzzDivide
+func FuzzDivide(f *testing.F) {
+	f.Add(10.0, 2.0)
+	f.Add(-7.5, 0.5)
+	f.Add(1.0, 0.0)
+	f.Add(0.0, -3.0)
+	
+	f.Fuzz(func(t *testing.T, a, b float64) {
+		// A panic here fails the fuzz run and saves the input to testdata/fuzz
+		result, err := Divide(a, b)
+		
+		if b == 0 {
+			if err == nil {
+				t.Errorf("Divide(%v, %v) = %v; want division by zero error", a, b, result)
+			}
+			return
+		}
+		if err != nil {
+			t.Errorf("Divide(%v, %v) returned error: %v", a, b, err)
+		}
+	})
+}
+
 func TestProcessUser(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -162,6 +252,47 @@ func TestProcessUser(t *testing.T) {
 	}
 }
 
+func TestProcessDataWith(t *testing.T) {
+	sum := func(acc, v int) int { return acc + v }
+	product := func(acc, v int) int { return acc * v }
+	maxOf := func(acc, v int) int {
+		if v > acc {
+			return v
+		}
+		return acc
+	}
+	
+	tests := []struct {
+		name     string
+		data     []int
+		reduce   func(acc, v int) int
+		initial  int
+		expected int
+	}{
+		{"sum", []int{1, 2, 3, 4}, sum, 0, 10},
+		{"product", []int{1, 2, 3, 4}, product, 1, 24},
+		{"max", []int{3, -1, 7, 2}, maxOf, math.MinInt, 7},
+		{"max of negatives", []int{-3, -1, -7}, maxOf, math.MinInt, -1},
+		{"empty returns initial", []int{}, product, 1, 1},
+		{"nil returns initial", nil, sum, 42, 42},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ProcessDataWith(tt.data, tt.reduce, tt.initial)
+			if result != tt.expected {
+				t.Errorf("ProcessDataWith(%v, %s, %d) = %d; want %d", 
+					tt.data, tt.name, tt.initial, result, tt.expected)
+			}
+		})
+	}
+	
+	// ProcessData is the sum specialization
+	if result := ProcessData([]int{1, 2, 3, 4}); result != 10 {
+		t.Errorf("ProcessData([1 2 3 4]) = %d; want 10", result)
+	}
+}
+
 func BenchmarkAdd(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Add(2, 3)
@@ -192,6 +323,43 @@ func BenchmarkProcessDataSizes(b *testing.B) {
 	}
 }
 
+func BenchmarkProcessDataParallel(b *testing.B) {
+	data := generateTestData(1000000)
+	
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ProcessData(data)
+		}
+	})
+	
+	for _, workers := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("workers_%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ProcessDataParallel(data, workers)
+			}
+		})
+	}
+}
+
+func TestProcessDataParallel(t *testing.T) {
+	sizes := []int{0, 1, 7, 1000, 1001, 99999}
+	workerCounts := []int{0, 1, 3, 4, 8, 16}
+	
+	for _, size := range sizes {
+		data := generateTestData(size)
+		expected := ProcessData(data)
+		for _, workers := range workerCounts {
+			t.Run(fmt.Sprintf("size_%d_workers_%d", size, workers), func(t *testing.T) {
+				result := ProcessDataParallel(data, workers)
+				if result != expected {
+					t.Errorf("ProcessDataParallel(%d items, %d) = %d; want %d", 
+						size, workers, result, expected)
+				}
+			})
+		}
+	}
+}
+
 func TestWithHelpers(t *testing.T) {
 	result := Add(2, 3)
 	assertEqual(t, result, 5)
@@ -217,6 +385,139 @@ func TestWithMock(t *testing.T) {
 	assertEqual(t, result.Name, "Alice")
 }
 
+func TestWithMockRecordsCalls(t *testing.T) {
+	mockService := &MockUserService{
+		users: map[int]*User{42: {ID: 42, Name: "Bob"}},
+	}
+	
+	_, err := ProcessUserWithService(mockService, 42)
+	assertNoError(t, err)
+	
+	// Verify the interaction, not just the result
+	if len(mockService.Calls) != 1 {
+		t.Errorf("GetUser called %d times; want 1 (calls: %v)", len(mockService.Calls), mockService.Calls)
+	}
+	mockService.AssertCalledWith(t, 42)
+}
+
+func TestAssertDeepEqualExplainsMismatch(t *testing.T) {
+	got := ProcessedUser{Name: "Alice", Age: 30, Status: "active"}
+	want := ProcessedUser{Name: "Alice", Age: 30, Status: "inactive"}
+	
+	diffs := deepEqualDiff(got, want, 0)
+	expected := []string{`ProcessedUser.Status: got "active", want "inactive"`}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Errorf("deepEqualDiff() = %q; want %q", diffs, expected)
+	}
+}
+
+func TestAssertDeepEqualPaths(t *testing.T) {
+	base := Sensor{
+		Name:     "north",
+		Position: Point{X: 1.5, Y: 2.5},
+		Readings: []float64{1, 2, 3},
+	}
+	
+	tests := []struct {
+		name     string
+		modify   func(s *Sensor)
+		epsilon  float64
+		expected []string
+	}{
+		{
+			name:     "identical",
+			modify:   func(s *Sensor) {},
+			expected: nil,
+		},
+		{
+			name:     "nested struct field",
+			modify:   func(s *Sensor) { s.Position.Y = 9 },
+			expected: []string{"Sensor.Position.Y: got 9, want 2.5"},
+		},
+		{
+			name:     "slice element",
+			modify:   func(s *Sensor) { s.Readings[1] = 20 },
+			expected: []string{"Sensor.Readings[1]: got 20, want 2"},
+		},
+		{
+			name:     "slice length",
+			modify:   func(s *Sensor) { s.Readings = s.Readings[:2] },
+			expected: []string{"Sensor.Readings: got length 2, want 3"},
+		},
+		{
+			name:     "float within tolerance",
+			modify: func(s *Sensor) {
+				s.Position.X += 0.0005
+				s.Readings[2] -= 0.0009
+			},
+			epsilon:  0.001,
+			expected: nil,
+		},
+		{
+			name:     "float outside tolerance",
+			modify:   func(s *Sensor) { s.Position.X += 0.01 },
+			epsilon:  0.001,
+			expected: []string{"Sensor.Position.X: got 1.51, want 1.5 (epsilon 0.001)"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := base
+			got.Readings = append([]float64(nil), base.Readings...)
+			tt.modify(&got)
+			
+			diffs := deepEqualDiff(got, base, tt.epsilon)
+			if !reflect.DeepEqual(diffs, tt.expected) {
+				t.Errorf("deepEqualDiff() = %q; want %q", diffs, tt.expected)
+			}
+		})
+	}
+	
+	AssertDeepEqual(t, Sensor{Name: "a", Position: Point{X: 1}}, Sensor{Name: "a", Position: Point{X: 1 + 1e-12}}, 1e-9)
+}
+
+func TestAssertDeepEqualMapOrder(t *testing.T) {
+	got := map[string]int{"d": 4, "b": 20, "a": 10, "c": 3, "e": 5}
+	want := map[string]int{"a": 1, "b": 2, "c": 3, "f": 6, "e": 50}
+	expected := []string{
+		"[a]: got 10, want 1",
+		"[b]: got 20, want 2",
+		"[e]: got 5, want 50",
+		"[f]: missing",
+		"[d]: unexpected",
+	}
+	
+	// Map iteration is random, so repeat to catch unstable ordering
+	for i := 0; i < 20; i++ {
+		if diffs := deepEqualDiff(got, want, 0); !reflect.DeepEqual(diffs, expected) {
+			t.Fatalf("deepEqualDiff() = %q; want %q", diffs, expected)
+		}
+	}
+}
+
+// TestProcessUserGolden snapshots ProcessUser's output for several users.
+// Review the diff in testdata/ before committing an -update.
+func TestProcessUserGolden(t *testing.T) {
+	users := []User{
+		{ID: 1, Name: "Alice", Age: 30},
+		{ID: 2, Name: "Bob", Age: 0},
+		{ID: 3, Name: "Carol", Age: -5},
+	}
+	
+	var out bytes.Buffer
+	for _, user := range users {
+		result, err := ProcessUser(user)
+		if err != nil {
+			fmt.Fprintf(&out, "%s: error: %v\n", user.Name, err)
+			continue
+		}
+		fmt.Fprintf(&out, "%s: %+v\n", user.Name, result)
+	}
+	
+	goldenEqual(t, out.Bytes(), filepath.Join("testdata", "process_user.golden"))
+}
+
 // Helper functions
 func assertEqual(t *testing.T, got, want interface{}) {
 	t.Helper()
@@ -239,6 +540,127 @@ func assertError(t *testing.T, err error) {
 	}
 }
 
+// goldenEqual compares got with the contents of goldenPath. With -update
+// it writes got to goldenPath instead, creating the directory if needed.
+func goldenEqual(t *testing.T, got []byte, goldenPath string) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+			t.Fatalf("creating golden directory: %v", err)
+		}
+		if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+			t.Fatalf("updating golden file: %v", err)
+		}
+		return
+	}
+	
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s\ngot:\n%s\nwant:\n%s", goldenPath, got, want)
+	}
+}
+
+// AssertDeepEqual is like assertEqual, but on mismatch it reports every
+// differing path (Sensor.Position.X, Sensor.Readings[2], ...) instead of
+// dumping both values. Floats are considered equal within epsilon.
+func AssertDeepEqual(t *testing.T, got, want interface{}, epsilon float64) {
+	t.Helper()
+	if diffs := deepEqualDiff(got, want, epsilon); len(diffs) > 0 {
+		t.Errorf("values differ:\n  %s", strings.Join(diffs, "\n  "))
+	}
+}
+
+// deepEqualDiff returns one line per differing path between got and want,
+// or nil if they are equal
+func deepEqualDiff(got, want interface{}, epsilon float64) []string {
+	gv, wv := reflect.ValueOf(got), reflect.ValueOf(want)
+	if !gv.IsValid() || !wv.IsValid() {
+		if gv.IsValid() != wv.IsValid() {
+			return []string{fmt.Sprintf("got %v, want %v", got, want)}
+		}
+		return nil
+	}
+	
+	path := gv.Type().Name()
+	var diffs []string
+	diffValues(path, gv, wv, epsilon, &diffs)
+	return diffs
+}
+
+func diffValues(path string, got, want reflect.Value, epsilon float64, diffs *[]string) {
+	if got.Type() != want.Type() {
+		*diffs = append(*diffs, fmt.Sprintf("%s: got type %v, want %v", path, got.Type(), want.Type()))
+		return
+	}
+	
+	switch got.Kind() {
+	case reflect.Struct:
+		for i := 0; i < got.NumField(); i++ {
+			field := got.Type().Field(i).Name
+			diffValues(path+"."+field, got.Field(i), want.Field(i), epsilon, diffs)
+		}
+	case reflect.Slice, reflect.Array:
+		if got.Len() != want.Len() {
+			*diffs = append(*diffs, fmt.Sprintf("%s: got length %d, want %d", path, got.Len(), want.Len()))
+			return
+		}
+		for i := 0; i < got.Len(); i++ {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), got.Index(i), want.Index(i), epsilon, diffs)
+		}
+	case reflect.Map:
+		for _, key := range sortedMapKeys(want) {
+			keyPath := fmt.Sprintf("%s[%v]", path, key)
+			if gotValue := got.MapIndex(key); gotValue.IsValid() {
+				diffValues(keyPath, gotValue, want.MapIndex(key), epsilon, diffs)
+			} else {
+				*diffs = append(*diffs, keyPath+": missing")
+			}
+		}
+		for _, key := range sortedMapKeys(got) {
+			if !want.MapIndex(key).IsValid() {
+				*diffs = append(*diffs, fmt.Sprintf("%s[%v]: unexpected", path, key))
+			}
+		}
+	case reflect.Pointer, reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() != want.IsNil() {
+				*diffs = append(*diffs, fmt.Sprintf("%s: got %v, want %v", path, got, want))
+			}
+			return
+		}
+		diffValues(path, got.Elem(), want.Elem(), epsilon, diffs)
+	case reflect.Float32, reflect.Float64:
+		if math.Abs(got.Float()-want.Float()) > epsilon {
+			line := fmt.Sprintf("%s: got %v, want %v", path, got, want)
+			if epsilon > 0 {
+				line += fmt.Sprintf(" (epsilon %v)", epsilon)
+			}
+			*diffs = append(*diffs, line)
+		}
+	case reflect.String:
+		if got.String() != want.String() {
+			*diffs = append(*diffs, fmt.Sprintf("%s: got %q, want %q", path, got, want))
+		}
+	default:
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: got %v, want %v", path, got, want))
+		}
+	}
+}
+
+// sortedMapKeys returns m's keys ordered by their printed form, so diffs
+// over maps come out in the same order on every run
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
+
 // Type definitions
 type User struct {
 	ID   int
@@ -252,6 +674,16 @@ type ProcessedUser struct {
 	Status string
 }
 
+type Point struct {
+	X, Y float64
+}
+
+type Sensor struct {
+	Name     string
+	Position Point
+	Readings []float64
+}
+
 type UserService interface {
 	GetUser(id int) (*User, error)
 }
@@ -259,16 +691,29 @@ type UserService interface {
 type MockUserService struct {
 	users map[int]*User
 	err   error
+	Calls []int  // ids passed to GetUser, in call order
 }
 
 // Method implementations
 func (m *MockUserService) GetUser(id int) (*User, error) {
+	m.Calls = append(m.Calls, id)
 	if m.err != nil {
 		return nil, m.err
 	}
 	return m.users[id], nil
 }
 
+// AssertCalledWith fails the test unless GetUser was called with id
+func (m *MockUserService) AssertCalledWith(t *testing.T, id int) {
+	t.Helper()
+	for _, call := range m.Calls {
+		if call == id {
+			return
+		}
+	}
+	t.Errorf("GetUser was not called with %d; calls: %v", id, m.Calls)
+}
+
 func Add(a, b int) int {
 	return a + b
 }
@@ -277,6 +722,10 @@ func Subtract(a, b int) int {
 	return a - b
 }
 
+func Multiply(a, b int) int {
+	return a * b
+}
+
 func Divide(a, b float64) (float64, error) {
 	if b == 0 {
 		return 0, errors.New("division by zero")
@@ -300,6 +749,29 @@ func ProcessUserWithService(service UserService, id int) (*User, error) {
 	return service.GetUser(id)
 }
 
+// ProcessDataParallel sums data like ProcessData, but splits it into
+// workers nearly equal chunks and sums each chunk in its own goroutine.
+// workers < 1 is treated as 1, and there are never more workers than
+// elements. Only worth it for large slices: goroutines aren't free.
+func ProcessDataParallel(data []int, workers int) int {
+	workers = max(1, min(workers, len(data)))
+	
+	partials := make([]int, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		// Chunk boundaries spread any remainder across the workers
+		start, end := w*len(data)/workers, (w+1)*len(data)/workers
+		wg.Add(1)
+		go func(w int, chunk []int) {
+			defer wg.Done()
+			partials[w] = ProcessData(chunk)
+		}(w, data[start:end])
+	}
+	wg.Wait()
+	
+	return ProcessData(partials)
+}
+
 func generateTestData(size int) []int {
 	data := make([]int, size)
 	for i := 0; i < size; i++ {
@@ -308,10 +780,18 @@ func generateTestData(size int) []int {
 	return data
 }
 
+// ProcessData sums data
 func ProcessData(data []int) int {
-	sum := 0
+	return ProcessDataWith(data, func(acc, v int) int { return acc + v }, 0)
+}
+
+// ProcessDataWith folds data into a single value: it starts from initial
+// and calls reduce with the running result and each element in turn.
+// An empty slice returns initial.
+func ProcessDataWith(data []int, reduce func(acc, v int) int, initial int) int {
+	acc := initial
 	for _, v := range data {
-		sum += v
+		acc = reduce(acc, v)
 	}
-	return sum
+	return acc
 }
@@ -0,0 +1,24 @@
+// Package idgen hands out unique, increasing IDs that are safe to request
+// from many goroutines at once.
+package idgen
+
+import "sync/atomic"
+
+// IDGenerator returns sequential IDs. It uses an atomic counter rather than
+// a mutex, so Next never blocks. The zero value is ready to use and starts
+// at 1.
+type IDGenerator struct {
+	last atomic.Int64
+}
+
+// NewIDGenerator creates a generator whose first ID is start
+func NewIDGenerator(start int64) *IDGenerator {
+	g := &IDGenerator{}
+	g.last.Store(start - 1)
+	return g
+}
+
+// Next returns the next ID
+func (g *IDGenerator) Next() int64 {
+	return g.last.Add(1)
+}
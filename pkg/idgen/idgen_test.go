@@ -0,0 +1,62 @@
+package idgen
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNextSequential(t *testing.T) {
+	var zero IDGenerator
+	for want := int64(1); want <= 3; want++ {
+		if got := zero.Next(); got != want {
+			t.Errorf("zero value Next() = %d; want %d", got, want)
+		}
+	}
+	
+	g := NewIDGenerator(1000)
+	for want := int64(1000); want <= 1002; want++ {
+		if got := g.Next(); got != want {
+			t.Errorf("NewIDGenerator(1000).Next() = %d; want %d", got, want)
+		}
+	}
+}
+
+// Run with -race to also check for data races
+func TestNextConcurrentUnique(t *testing.T) {
+	const goroutines, perGoroutine = 50, 200
+	g := NewIDGenerator(1)
+	
+	results := make([][]int64, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids := make([]int64, perGoroutine)
+			for j := range ids {
+				ids[j] = g.Next()
+			}
+			results[i] = ids
+		}(i)
+	}
+	wg.Wait()
+	
+	seen := make(map[int64]bool, goroutines*perGoroutine)
+	for _, ids := range results {
+		for _, id := range ids {
+			if seen[id] {
+				t.Fatalf("duplicate ID %d", id)
+			}
+			seen[id] = true
+		}
+	}
+	if len(seen) != goroutines*perGoroutine {
+		t.Errorf("got %d unique IDs; want %d", len(seen), goroutines*perGoroutine)
+	}
+	for id := int64(1); id <= goroutines*perGoroutine; id++ {
+		if !seen[id] {
+			t.Errorf("ID %d was never handed out", id)
+			break
+		}
+	}
+}
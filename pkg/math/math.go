@@ -0,0 +1,116 @@
+// Package math provides the integer arithmetic used by the packages example.
+// It shadows the standard library's math package name on purpose, to show
+// that imports are identified by path, not by name.
+package math
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	stdmath "math"  // Aliased: this package is also called math
+)
+
+// Pi is an exported package-level variable
+var Pi = 3.14159
+
+// Config describes how the package was set up
+type Config struct {
+	Name    string
+	Version string
+}
+
+// config is unexported; callers read it through GetConfig
+var config Config
+
+// init runs once, before main, when the package is first imported
+func init() {
+	config = Config{Name: "math", Version: "1.0.0"}
+}
+
+// GetConfig returns the configuration set up by init
+func GetConfig() Config {
+	return config
+}
+
+// Add returns a + b
+func Add(a, b int) int {
+	return a + b
+}
+
+// Subtract returns a - b
+func Subtract(a, b int) int {
+	return a - b
+}
+
+// Multiply returns a * b
+func Multiply(a, b int) int {
+	return a * b
+}
+
+// Divide returns a / b, truncated toward zero like Go's integer division.
+// It returns an error instead of panicking when b is zero.
+func Divide(a, b int) (int, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+// Max returns the larger of a and b
+func Max[T cmp.Ordered](a, b T) T {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Min returns the smaller of a and b
+func Min[T cmp.Ordered](a, b T) T {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Clamp limits v to the range [lo, hi]. If lo > hi the bounds are swapped,
+// so Clamp(v, 10, 0) behaves like Clamp(v, 0, 10).
+func Clamp[T cmp.Ordered](v, lo, hi T) T {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return Min(Max(v, lo), hi)
+}
+
+// Pow returns base raised to exp using exponentiation by squaring, which
+// needs O(log |exp|) multiplications. Any base to the power 0 is 1, and a
+// negative exp gives the reciprocal, so Pow(0, -1) is +Inf.
+func Pow(base float64, exp int) float64 {
+	// Work on the magnitude as a uint: -exp overflows for math.MinInt,
+	// but uint(-exp) is still the right value (2^63 on 64-bit platforms)
+	n := uint(exp)
+	if exp < 0 {
+		n = uint(-exp)
+	}
+	
+	result := 1.0
+	for n > 0 {
+		if n&1 == 1 {
+			result *= base
+		}
+		base *= base
+		n >>= 1
+	}
+	
+	if exp < 0 {
+		return 1 / result
+	}
+	return result
+}
+
+// Sqrt returns the square root of x, or an error if x is negative
+func Sqrt(x float64) (float64, error) {
+	if x < 0 {
+		return 0, fmt.Errorf("square root of negative number %g", x)
+	}
+	return stdmath.Sqrt(x), nil
+}
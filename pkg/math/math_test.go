@@ -0,0 +1,158 @@
+package math
+
+import (
+	stdmath "math"
+	"testing"
+)
+
+func TestMultiply(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     int
+		expected int
+	}{
+		{"positive numbers", 3, 4, 12},
+		{"zero operand", 7, 0, 0},
+		{"one negative", -3, 4, -12},
+		{"both negative", -3, -4, 12},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Multiply(tt.a, tt.b); result != tt.expected {
+				t.Errorf("Multiply(%d, %d) = %d; want %d", tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDivide(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     int
+		expected int
+		wantErr  bool
+	}{
+		{"exact division", 10, 2, 5, false},
+		{"truncates", 7, 2, 3, false},
+		{"negative dividend", -7, 2, -3, false},
+		{"negative divisor", 10, -2, -5, false},
+		{"both negative", -10, -2, 5, false},
+		{"zero dividend", 0, 5, 0, false},
+		{"zero divisor", 10, 0, 0, true},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Divide(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Divide(%d, %d) error = %v; wantErr %v", tt.a, tt.b, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("Divide(%d, %d) = %d; want %d", tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	if got := Max(3, 7); got != 7 {
+		t.Errorf("Max(3, 7) = %d; want 7", got)
+	}
+	if got := Min(3, 7); got != 3 {
+		t.Errorf("Min(3, 7) = %d; want 3", got)
+	}
+	if got := Max(-1.5, -2.5); got != -1.5 {
+		t.Errorf("Max(-1.5, -2.5) = %v; want -1.5", got)
+	}
+	if got := Min("go", "gopher"); got != "go" {
+		t.Errorf("Min(\"go\", \"gopher\") = %q; want \"go\"", got)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	tests := []struct {
+		name      string
+		v, lo, hi float64
+		expected  float64
+	}{
+		{"inside range", 5, 0, 10, 5},
+		{"below range", -3, 0, 10, 0},
+		{"above range", 12.5, 0, 10, 10},
+		{"on a bound", 10, 0, 10, 10},
+		{"equal bounds", 7, 4, 4, 4},
+		{"lo > hi swaps bounds", 12, 10, 0, 10},
+		{"lo > hi inside range", 5, 10, 0, 5},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Clamp(tt.v, tt.lo, tt.hi); result != tt.expected {
+				t.Errorf("Clamp(%v, %v, %v) = %v; want %v", tt.v, tt.lo, tt.hi, result, tt.expected)
+			}
+		})
+	}
+	
+	if got := Clamp(-5, 1, 3); got != 1 {
+		t.Errorf("Clamp(-5, 1, 3) = %d; want 1", got)
+	}
+}
+
+func TestPow(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     float64
+		exp      int
+		expected float64
+	}{
+		{"power of two", 2, 10, 1024},
+		{"odd exponent", 3, 5, 243},
+		{"exponent one", 7, 1, 7},
+		{"zero exponent", 5, 0, 1},
+		{"zero base zero exponent", 0, 0, 1},
+		{"negative exponent", 2, -2, 0.25},
+		{"negative base odd exponent", -2, 3, -8},
+		{"fractional base", 0.5, 3, 0.125},
+		{"most negative exponent", 2, stdmath.MinInt, 0},
+		{"most negative exponent of one", 1, stdmath.MinInt, 1},
+		{"most negative exponent of minus one", -1, stdmath.MinInt, 1},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Pow(tt.base, tt.exp); result != tt.expected {
+				t.Errorf("Pow(%v, %d) = %v; want %v", tt.base, tt.exp, result, tt.expected)
+			}
+		})
+	}
+	
+	if result := Pow(0, -1); !stdmath.IsInf(result, 1) {
+		t.Errorf("Pow(0, -1) = %v; want +Inf", result)
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	tests := []struct {
+		name     string
+		x        float64
+		expected float64
+		wantErr  bool
+	}{
+		{"perfect square", 16, 4, false},
+		{"zero", 0, 0, false},
+		{"non-integer result", 2, stdmath.Sqrt2, false},
+		{"negative input", -4, 0, true},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Sqrt(tt.x)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sqrt(%v) error = %v; wantErr %v", tt.x, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("Sqrt(%v) = %v; want %v", tt.x, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Package user provides the User type used by the packages example.
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// User is a person with a name and an age. The struct tags control the
+// JSON field names used by encoding/json.
+type User struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+// New creates a User
+func New(name string, age int) *User {
+	return &User{Name: name, Age: age}
+}
+
+// GetName returns the user's name
+func (u *User) GetName() string {
+	return u.Name
+}
+
+// SetAge updates the user's age. Negative ages are rejected and leave
+// the user unchanged.
+func (u *User) SetAge(age int) error {
+	if age < 0 {
+		return fmt.Errorf("invalid age %d: must not be negative", age)
+	}
+	u.Age = age
+	return nil
+}
+
+// FromJSON decodes a User produced by json.Marshal. It returns an error for
+// malformed JSON and, like SetAge, for a negative age.
+func FromJSON(data []byte) (*User, error) {
+	var u User
+	if err := json.Unmarshal(data, &u); err != nil {
+		return nil, fmt.Errorf("decoding user: %w", err)
+	}
+	if u.Age < 0 {
+		return nil, fmt.Errorf("decoding user: invalid age %d: must not be negative", u.Age)
+	}
+	return &u, nil
+}
@@ -0,0 +1,80 @@
+package user
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	u := New("Alice", 30)
+	if u.Name != "Alice" || u.Age != 30 {
+		t.Errorf("New(\"Alice\", 30) = %+v; want {Name:Alice Age:30}", u)
+	}
+	if got := u.GetName(); got != "Alice" {
+		t.Errorf("GetName() = %q; want \"Alice\"", got)
+	}
+}
+
+func TestSetAge(t *testing.T) {
+	tests := []struct {
+		name     string
+		age      int
+		wantErr  bool
+		expected int
+	}{
+		{"valid age", 31, false, 31},
+		{"zero", 0, false, 0},
+		{"negative", -1, true, 30},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := New("Alice", 30)
+			err := u.SetAge(tt.age)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetAge(%d) error = %v; wantErr %v", tt.age, err, tt.wantErr)
+			}
+			if u.Age != tt.expected {
+				t.Errorf("after SetAge(%d), Age = %d; want %d", tt.age, u.Age, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	original := New("Alice", 30)
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("json.Marshal(%+v) error: %v", original, err)
+	}
+	if expected := `{"name":"Alice","age":30}`; string(data) != expected {
+		t.Errorf("json.Marshal(%+v) = %s; want %s", original, data, expected)
+	}
+	
+	decoded, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("FromJSON(%s) error: %v", data, err)
+	}
+	if *decoded != *original {
+		t.Errorf("round trip = %+v; want %+v", decoded, original)
+	}
+}
+
+func TestFromJSONErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"malformed", `{"name": "Alice",`},
+		{"wrong type", `{"name": "Alice", "age": "thirty"}`},
+		{"negative age", `{"name": "Alice", "age": -1}`},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if u, err := FromJSON([]byte(tt.input)); err == nil {
+				t.Errorf("FromJSON(%s) = %+v; want error", tt.input, u)
+			}
+		})
+	}
+}